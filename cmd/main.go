@@ -1,49 +1,120 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/vinaygaykar/cool-lexicon/lexicon/pkg"
 	"github.com/vinaygaykar/cool-lexicon/utils"
 	"github.com/vinaygaykar/cool-lexicon/utils/io"
+	"github.com/vinaygaykar/cool-lexicon/utils/io/tokenizer"
 )
 
 // A ProgramInput holds all the input values provided to the program.
 type ProgramArgs struct {
-	configFilePath           string // Location of the config file
-	shouldPerformSetupChecks bool   // true if setup checks should be performed
-	isFileBasedInput         bool   // true if the input should be read from the given file instead of the command line
-	outputFolderPath         string // true if the output should be printed to file instead of the command line
+	configFilePath           string        // Location of the config file
+	shouldPerformSetupChecks bool          // true if setup checks should be performed
+	shouldNormalize          bool          // true if the existing words should be rewritten to the configured normalization form
+	isFileBasedInput         bool          // true if the input should be read from the given file instead of the command line
+	isProseInput             bool          // true if the input is prose from which Devanagari words should be extracted
+	outputFolderPath         string        // true if the output should be printed to file instead of the command line
+	timeout                  time.Duration // time limit for all the operations together, zero means no limit
+	distance                 int           // maximum count of akshara edits between a word and the similar words
+	aksharas                 int           // count of aksharas of the words searched by their count
+	syllables                int           // count of trailing aksharas compared to find the rhyming words
+	rhyme                    string        // strictness of the rhymes, one of `vowel`, `final` & `exact`
+	suggestions              int           // maximum count of suggestions for every unknown word found by the spell check
+	minAksharas              int           // minimum count of aksharas of the words found by every search, zero means no limit
+	maxAksharas              int           // maximum count of aksharas of the words found by every search, zero means no limit
+	minLength                int           // minimum count of characters of the words found by every search, zero means no limit
+	maxLength                int           // maximum count of characters of the words found by every search, zero means no limit
+	exclude                  string        // comma separated words which are never returned by the searches
+	limit                    int           // maximum count of words returned by every search for every input word, zero means no limit
+	page                     int           // page of `limit` words returned by every search, starting from 1
+	after                    string        // cursor, the words returned by every search follow it
+	order                    string        // order of the words returned by every search, dictionary, codepoint or db
 
 	opLookup             string // value of the LOOKUP operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
 	opSearchStartingWith string // value of the SEARCH START WITH operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
 	opSearchEndingWith   string // value of the SEARCH END WITH operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opSearchContaining   string // value of the SEARCH CONTAINING operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opSearchAksharas     string // value of the SEARCH BY AKSHARA COUNT operation, if `isFileBasedInput` is true then this is file location else this is a prefix to operate on
+	opSearchPattern      string // value of the SEARCH PATTERN operation, if `isFileBasedInput` is true then this is file location else this is a pattern to operate on
+	opSearchRegexp       string // value of the SEARCH REGEXP operation, if `isFileBasedInput` is true then this is file location else this is a regular expression to operate on
+	opSearchSimilar      string // value of the SEARCH SIMILAR operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opSearchSounding     string // value of the SEARCH SOUNDING LIKE operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opSearchRhymes       string // value of the SEARCH RHYMES operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opSearchAnagrams     string // value of the SEARCH ANAGRAMS operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opSearchTiles        string // value of the SEARCH TILES operation, if `isFileBasedInput` is true then this is file location else these are the tiles to operate on
+	opSpellCheck         string // value of the SPELL CHECK operation, this is always a file location, or `-` for the standard input
 	opAdd                string // value of the ADD operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
+	opRemove             string // value of the REMOVE operation, if `isFileBasedInput` is true then this is file location else this is a word to operate on
 }
 
+const (
+	// chunkSize is the maximum count of input words processed by a single lexicon operation,
+	// input is streamed chunk by chunk so that huge files are never held in memory all at once.
+	chunkSize = 10000
+)
+
 var (
 	args          ProgramArgs
-	wordSupplier  io.SupplyInput
+	wordSupplier  io.SupplyInputStream
 	outputPrinter io.ConsumeOutput
+	split         bufio.SplitFunc // splits the input into words, nil for whitespace delimited words
 )
 
 func init() {
 	flag.BoolVar(&args.shouldPerformSetupChecks, "check", false, "Setup all necessary configs if required. This is optional, if the all configs are already setup correctly this operation will have no effect")
 
+	flag.BoolVar(&args.shouldNormalize, "nm", false, "Rewrite the existing words of the lexicon to the configured normalization form, words which become the same are stored once. Needed only once for the words added before normalization was configured")
+
 	flag.BoolVar(&args.isFileBasedInput, "if", false, "This flag indicates that input words to every operation should be taken from the file passed as value to individual operation")
+	flag.BoolVar(&args.isProseInput, "tk", false, "This flag indicates that input to every operation is prose, Devanagari words are extracted from it dropping punctuation, numerals and other scripts")
 	flag.StringVar(&args.outputFolderPath, "of", "", "This flag indicates that output to every operation should be printed to files (created for every operation) at given path")
 
 	flag.StringVar(&args.configFilePath, "cfg", "config.json", "Config file location")
+	flag.DurationVar(&args.timeout, "timeout", 0, "Time limit for all the operations together, e.g. 30s or 5m. Operations still running at the limit are abandoned")
+
+	flag.IntVar(&args.minAksharas, "min-aksharas", 0, "Minimum count of aksharas of the words found by every search (except -sk), 0 means no limit")
+	flag.IntVar(&args.maxAksharas, "max-aksharas", 0, "Maximum count of aksharas of the words found by every search (except -sk), 0 means no limit")
+	flag.IntVar(&args.minLength, "min-length", 0, "Minimum count of characters (code points) of the words found by every search (except -sk), 0 means no limit")
+	flag.IntVar(&args.maxLength, "max-length", 0, "Maximum count of characters (code points) of the words found by every search (except -sk), 0 means no limit")
+	flag.StringVar(&args.exclude, "exclude", "", "Comma separated words which are never returned by the searches (except -sk)")
+	flag.IntVar(&args.limit, "limit", 0, "Maximum count of words returned by every search (except -sk) for every input word, 0 means no limit. The next cursor of a full page is printed along with the words")
+	flag.IntVar(&args.page, "page", 1, "Page of -limit words returned by every search, starting from 1")
+	flag.StringVar(&args.after, "after", "", "Cursor of the page returned by every search, i.e. the last word of the previous page as printed by the search, takes the place of -page")
+	flag.StringVar(&args.order, "order", string(lexicon.OrderDictionary), "Order of the words returned by every search (except -sk), dictionary for the Devanagari dictionary order, codepoint for the order of the code points or db for the order of the storage, e.g. collation of the database")
 
 	flag.StringVar(&args.opLookup, "ex", "", "Check if the given word exist")
 	flag.StringVar(&args.opSearchStartingWith, "ss", "", "Search the lexicon to find words that start with given substring")
 	flag.StringVar(&args.opSearchEndingWith, "se", "", "Search the lexicon to find words that end with given substring")
+	flag.StringVar(&args.opSearchContaining, "sc", "", "Search the lexicon to find words that contain given substring anywhere")
+	flag.StringVar(&args.opSearchAksharas, "sn", "", "Search the lexicon to find words that start with given prefix and have exactly -aksharas aksharas")
+	flag.IntVar(&args.aksharas, "aksharas", 1, "Count of aksharas of the words found by -sn")
+	flag.StringVar(&args.opSearchPattern, "sp", "", "Search the lexicon to find words that match given pattern, where ? matches exactly one akshara and * matches any run of aksharas")
+	flag.StringVar(&args.opSearchRegexp, "sr", "", "Search the lexicon to find words that match given regular expression (RE2 syntax)")
+	flag.StringVar(&args.opSearchSimilar, "sf", "", "Search the lexicon to find words similar to given word, within -distance akshara edits, closest first")
+	flag.IntVar(&args.distance, "distance", 1, "Maximum count of akshara insertions, deletions, substitutions or swaps between a word and the similar words found by -sf")
+	flag.StringVar(&args.opSearchSounding, "sl", "", "Search the lexicon to find words that sound like given word, ignoring aspiration, sibilants, vowel length, nasalisation, nukta and virama")
+	flag.StringVar(&args.opSearchRhymes, "rh", "", "Search the lexicon to find words that rhyme with given word, comparing the last -syllables aksharas as per -rhyme strictness")
+	flag.IntVar(&args.syllables, "syllables", 2, "Count of trailing aksharas compared to find the words rhyming with a word by -rh")
+	flag.StringVar(&args.rhyme, "rhyme", string(lexicon.RhymeFinal), "Strictness of the rhymes found by -rh: vowel (same vowels), final (same last akshara and vowels before it) or exact (same aksharas)")
+	flag.StringVar(&args.opSearchAnagrams, "sa", "", "Search the lexicon to find words made of exactly the same aksharas as given word, in any order")
+	flag.StringVar(&args.opSearchTiles, "st", "", fmt.Sprintf("Search the lexicon to find words that can be formed using some of the given aksharas (tiles), every tile at most once. At most %d tiles", lexicon.MaxTiles))
+	flag.StringVar(&args.opSpellCheck, "sk", "", "Spell check the text in given file location, every word not in the lexicon is reported with its line:column and suggestions")
+	flag.IntVar(&args.suggestions, "suggestions", 5, "Maximum count of suggestions for every unknown word found by -sk")
 	flag.StringVar(&args.opAdd, "ad", "", "Add words present in given file location to lexicon")
+	flag.StringVar(&args.opRemove, "rm", "", "Remove the given words from lexicon")
 }
 
 func main() {
@@ -52,17 +123,34 @@ func main() {
 	sanitizeInputs()
 	validateInputs()
 
+	// exit only once run has closed the lexicon, which may still have to save its words
+	if err := run(); err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+// run performs the selected operations one after the other and stops at the first failing operation.
+func run() error {
+	if args.isProseInput {
+		split = tokenizer.ScanDevanagariWords
+	}
+
 	if args.isFileBasedInput {
-		wordSupplier = &io.SupplyWordsFromFile{}
+		wordSupplier = &io.SupplyWordsFromFile{Split: split}
 	} else {
-		wordSupplier = &io.SupplyWordsFromCLI{}
+		wordSupplier = &io.SupplyWordsFromCLI{Split: split}
 	}
 
-	if len(args.outputFolderPath) == 0 {
+	if len(args.outputFolderPath) == 0 && !io.IsTerminal(os.Stdout) {
+		// output is piped to another program, keep it plain and keep the log free of timestamps
+		log.SetFlags(0)
+		outputPrinter = &io.ConsumeOutputToStdout{}
+	} else if len(args.outputFolderPath) == 0 {
 		outputPrinter = &io.ConsumeOutputToLog{}
 	} else {
 		outputPrinter = &io.ConsumeOutputToFile{OutputFolderPath: args.outputFolderPath}
 	}
+	defer outputPrinter.Close()
 
 	cfg := configs.ReadConfigs(args.configFilePath)
 	if args.shouldPerformSetupChecks {
@@ -72,10 +160,42 @@ func main() {
 	lxc := lexicon.GetInstance(cfg)
 	defer lxc.Close()
 
-	tryOperateLookup(lxc)
-	tryOperateGetAllStartingWith(lxc)
-	tryOperateGetAllEndingWith(lxc)
-	tryOperateAdd(lxc)
+	// abandon running operations on Ctrl+C or once the time limit is reached
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if args.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, args.timeout)
+		defer cancel()
+	}
+
+	operations := []func(ctx context.Context, lxc lexicon.Lexicon) error{
+		func(ctx context.Context, lxc lexicon.Lexicon) error {
+			return tryOperateNormalize(ctx, lxc, cfg.Normalization)
+		},
+		tryOperateLookup,
+		tryOperateGetAllStartingWith,
+		tryOperateGetAllEndingWith,
+		tryOperateGetAllContaining,
+		tryOperateGetAllOfAksharaCount,
+		tryOperateGetAllMatching,
+		tryOperateGetAllMatchingRegexp,
+		tryOperateGetAllSimilarTo,
+		tryOperateGetAllSoundingLike,
+		tryOperateGetAllRhymingWith,
+		tryOperateGetAllAnagramsOf,
+		tryOperateGetAllWordsFromTiles,
+		tryOperateSpellCheck,
+		tryOperateAdd,
+		tryOperateRemove,
+	}
+	for _, operate := range operations {
+		if err := operate(ctx, lxc); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func sanitizeInputs() {
@@ -84,7 +204,18 @@ func sanitizeInputs() {
 	args.opLookup = strings.TrimSpace(args.opLookup)
 	args.opSearchStartingWith = strings.TrimSpace(args.opSearchStartingWith)
 	args.opSearchEndingWith = strings.TrimSpace(args.opSearchEndingWith)
+	args.opSearchContaining = strings.TrimSpace(args.opSearchContaining)
+	args.opSearchAksharas = strings.TrimSpace(args.opSearchAksharas)
+	args.opSearchPattern = strings.TrimSpace(args.opSearchPattern)
+	args.opSearchRegexp = strings.TrimSpace(args.opSearchRegexp)
+	args.opSearchSimilar = strings.TrimSpace(args.opSearchSimilar)
+	args.opSearchSounding = strings.TrimSpace(args.opSearchSounding)
+	args.opSearchRhymes = strings.TrimSpace(args.opSearchRhymes)
+	args.opSearchAnagrams = strings.TrimSpace(args.opSearchAnagrams)
+	args.opSearchTiles = strings.TrimSpace(args.opSearchTiles)
+	args.opSpellCheck = strings.TrimSpace(args.opSpellCheck)
 	args.opAdd = strings.TrimSpace(args.opAdd)
+	args.opRemove = strings.TrimSpace(args.opRemove)
 	args.outputFolderPath = strings.TrimSpace(args.outputFolderPath)
 }
 
@@ -100,71 +231,455 @@ func validateInputs() {
 	}
 
 	if !args.shouldPerformSetupChecks && // not performing checks
+		!args.shouldNormalize && // not performing normalization
 		len(args.opLookup) == 0 && // not performing lookup
 		len(args.opSearchStartingWith) == 0 && // not performing search starts
 		len(args.opSearchEndingWith) == 0 && // not performing search end
-		len(args.opAdd) == 0 { // not performing add
+		len(args.opSearchContaining) == 0 && // not performing search containing
+		len(args.opSearchAksharas) == 0 && // not performing search by akshara count
+		len(args.opSearchPattern) == 0 && // not performing search pattern
+		len(args.opSearchRegexp) == 0 && // not performing search regexp
+		len(args.opSearchSimilar) == 0 && // not performing search similar
+		len(args.opSearchSounding) == 0 && // not performing search sounding like
+		len(args.opSearchRhymes) == 0 && // not performing search rhymes
+		len(args.opSearchAnagrams) == 0 && // not performing search anagrams
+		len(args.opSearchTiles) == 0 && // not performing search tiles
+		len(args.opSpellCheck) == 0 && // not performing spell check
+		len(args.opAdd) == 0 && // not performing add
+		len(args.opRemove) == 0 { // not performing remove
 		flag.PrintDefaults() // then what are you doing run this executable?
 		log.Panic("no operation provided")
 	}
+
+	// standard input can be read only once
+	stdinReaders := 0
+	for _, value := range operationValues() {
+		if value == io.StdinValue {
+			stdinReaders++
+		}
+	}
+	if stdinReaders > 1 {
+		log.Panic("standard input (-) can be the input of only one operation")
+	}
+
+	if args.page < 1 {
+		log.Panic("page must be 1 or more")
+	} else if args.page > 1 && args.limit == 0 {
+		log.Panic("page needs a limit")
+	} else if args.page > 1 && len(args.after) != 0 {
+		log.Panic("page and cursor cannot be used together")
+	}
+
+	if err := searchOptions().Validate(); err != nil {
+		log.Panic(err.Error())
+	}
 }
 
-func tryOperateLookup(lxc lexicon.Lexicon) {
-	words, err := wordSupplier.Get(args.opLookup)
-	if len(words) == 0 || errors.Is(io.ErrNoInputValue, err) {
-		return // this operation was not selected
-	} else if err != nil {
-		log.Printf("could not perform 'exists' for input (%s), error: %s\n", args.opLookup, err.Error())
+// searchOptions returns the options which narrow down the words found by every search.
+func searchOptions() lexicon.SearchOptions {
+	return lexicon.SearchOptions{
+		MinAksharas: args.minAksharas,
+		MaxAksharas: args.maxAksharas,
+		MinLength:   args.minLength,
+		MaxLength:   args.maxLength,
+		Exclude: strings.FieldsFunc(args.exclude, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		}),
+		Limit:  args.limit,
+		Offset: (args.page - 1) * args.limit,
+		After:  args.after,
+		Order:  lexicon.Order(args.order),
 	}
+}
 
-	if response, err := lxc.Lookup(words...); err == nil {
-		outputPrinter.ConsumeWords("ex", response)
-	} else {
-		log.Printf("could not perform 'exists' for input (%s), error: %s\n", args.opLookup, err.Error())
+// consumeSearches consumes the result of a search operation along with the cursor of the next page of every full page.
+func consumeSearches(operation string, searches *map[string][]string) {
+	outputPrinter.ConsumeMapOfWords(operation, searches)
+
+	options := searchOptions()
+	cursors := make(map[string]string)
+	for key, words := range *searches {
+		if next := options.Next(words); len(next) != 0 {
+			cursors[key] = next
+		}
+	}
+	if len(cursors) != 0 {
+		outputPrinter.ConsumeNextPages(operation, &cursors)
 	}
 }
 
-func tryOperateGetAllStartingWith(lxc lexicon.Lexicon) {
-	words, err := wordSupplier.Get(args.opSearchStartingWith)
-	if len(words) == 0 || errors.Is(io.ErrNoInputValue, err) {
-		return // this operation was not selected
-	} else if err != nil {
-		log.Printf("could not perform 'search starts with' for input (%s), error: %s\n", args.opSearchStartingWith, err.Error())
+// operationValues returns values of all the operations, selected or not.
+func operationValues() []string {
+	return []string{args.opLookup, args.opSearchStartingWith, args.opSearchEndingWith, args.opSearchContaining, args.opSearchAksharas, args.opSearchPattern, args.opSearchRegexp, args.opSearchSimilar, args.opSearchSounding, args.opSearchRhymes, args.opSearchAnagrams, args.opSearchTiles, args.opSpellCheck, args.opAdd, args.opRemove}
+}
+
+// forEachChunk streams the input words of an operation, from the standard input if `rawValue` is `-`, and invokes `fn` for every
+// chunk of at most chunkSize words.
+// If `rawValue` is empty, i.e. the operation was not selected, then io.ErrNoInputValue is returned.
+func forEachChunk(rawValue string, fn func(words []string) error) error {
+	next, closeInput, err := chunks(rawValue)
+	if err != nil {
+		return err
 	}
+	defer closeInput()
 
-	if searches, err := lxc.GetAllWordsStartingWith(words...); err == nil {
-		outputPrinter.ConsumeMapOfWords("ss", searches)
-	} else {
-		log.Fatalf("could not perform 'search starts with' for input (%s), error: %s\n", args.opSearchStartingWith, err.Error())
+	for {
+		words, err := next()
+		if err != nil || len(words) == 0 {
+			return err
+		}
+		if err = fn(words); err != nil {
+			return err
+		}
 	}
 }
 
-func tryOperateGetAllEndingWith(lxc lexicon.Lexicon) {
-	words, err := wordSupplier.Get(args.opSearchEndingWith)
-	if len(words) == 0 || errors.Is(io.ErrNoInputValue, err) {
-		return // this operation was not selected
-	} else if err != nil {
-		log.Printf("could not perform 'search ends with' for input (%s), error: %s\n", args.opSearchEndingWith, err.Error())
+// chunks streams the input words of an operation, see forEachChunk. It returns a function which returns the next chunk
+// of at most chunkSize words, no words once the input is over, along with a function which closes the input.
+func chunks(rawValue string) (next func() ([]string, error), closeInput func(), err error) {
+	supplier := wordSupplier
+	if strings.TrimSpace(rawValue) == io.StdinValue {
+		supplier = &io.SupplyWordsFromStdin{Split: split}
 	}
 
-	if searches, err := lxc.GetAllWordsEndingWith(words...); err == nil {
-		outputPrinter.ConsumeMapOfWords("se", searches)
-	} else {
-		log.Fatalf("could not perform 'search ends with' for input (%s), error: %s\n", args.opSearchEndingWith, err.Error())
+	it, err := supplier.Stream(rawValue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chunk := make([]string, 0, chunkSize)
+	next = func() ([]string, error) {
+		chunk = chunk[:0]
+		for len(chunk) < chunkSize && it.Next() {
+			chunk = append(chunk, it.Word())
+		}
+
+		return chunk, it.Err()
+	}
+
+	return next, func() { it.Close() }, nil
+}
+
+// tryOperateNormalize rewrites the existing words to the normalization `form`.
+func tryOperateNormalize(ctx context.Context, lxc lexicon.Lexicon, form string) error {
+	if !args.shouldNormalize {
+		return nil
+	}
+
+	normalize, err := lexicon.Normalizer(form)
+	if err != nil {
+		return fmt.Errorf("could not perform 'normalize', error: %w", err)
+	} else if normalize == nil {
+		return errors.New("could not perform 'normalize', normalization is disabled in the configs")
+	}
+
+	rewritten, err := lxc.RewriteContext(ctx, normalize)
+	if err != nil {
+		return fmt.Errorf("could not perform 'normalize', error: %w", err)
+	}
+
+	log.Printf("normalize operation completed, %d words rewritten\n", rewritten)
+	return nil
+}
+
+func tryOperateLookup(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opLookup, func(words []string) error {
+		response, err := lxc.LookupContext(ctx, words...)
+		if err == nil {
+			outputPrinter.ConsumeWords("ex", response)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'exists' for input (%s), error: %w", args.opLookup, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllStartingWith(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchStartingWith, func(words []string) error {
+		searches, err := lxc.GetAllWordsStartingWithContext(ctx, searchOptions(), words...)
+		if err == nil {
+			consumeSearches("ss", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search starts with' for input (%s), error: %w", args.opSearchStartingWith, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllEndingWith(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchEndingWith, func(words []string) error {
+		searches, err := lxc.GetAllWordsEndingWithContext(ctx, searchOptions(), words...)
+		if err == nil {
+			consumeSearches("se", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search ends with' for input (%s), error: %w", args.opSearchEndingWith, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllContaining(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchContaining, func(words []string) error {
+		searches, err := lxc.GetAllWordsContainingContext(ctx, searchOptions(), words...)
+		if err == nil {
+			consumeSearches("sc", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search containing' for input (%s), error: %w", args.opSearchContaining, err)
 	}
+
+	return nil
+}
+
+func tryOperateGetAllOfAksharaCount(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchAksharas, func(prefixes []string) error {
+		searches, err := lxc.GetAllWordsOfAksharaCountContext(ctx, searchOptions(), args.aksharas, prefixes...)
+		if err == nil {
+			consumeSearches("sn", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search by akshara count' for input (%s), error: %w", args.opSearchAksharas, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllMatching(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchPattern, func(patterns []string) error {
+		searches, err := lxc.GetAllWordsMatchingContext(ctx, searchOptions(), patterns...)
+		if err == nil {
+			consumeSearches("sp", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search pattern' for input (%s), error: %w", args.opSearchPattern, err)
+	}
+
+	return nil
+}
+
+// tryOperateGetAllMatchingRegexp searches the regular expressions, results of an expression matching too many words are
+// printed partially along with a warning.
+func tryOperateGetAllMatchingRegexp(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchRegexp, func(expressions []string) error {
+		searches, err := lxc.GetAllWordsMatchingRegexpContext(ctx, searchOptions(), expressions...)
+		if errors.Is(err, lexicon.ErrTooManyMatches) {
+			log.Printf("search regexp: %s, only the first %d words are printed for such expressions\n", err.Error(), lexicon.MaxRegexpMatches)
+			err = nil
+		}
+		if err == nil {
+			consumeSearches("sr", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search regexp' for input (%s), error: %w", args.opSearchRegexp, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllSimilarTo(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchSimilar, func(words []string) error {
+		searches, err := lxc.GetAllWordsSimilarToContext(ctx, searchOptions(), args.distance, words...)
+		if err == nil {
+			consumeSearches("sf", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search similar' for input (%s), error: %w", args.opSearchSimilar, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllSoundingLike(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchSounding, func(words []string) error {
+		searches, err := lxc.GetAllWordsSoundingLikeContext(ctx, searchOptions(), words...)
+		if err == nil {
+			consumeSearches("sl", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search sounding like' for input (%s), error: %w", args.opSearchSounding, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllRhymingWith(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchRhymes, func(words []string) error {
+		searches, err := lxc.GetAllWordsRhymingWithContext(ctx, searchOptions(), args.syllables, lexicon.RhymeStrictness(args.rhyme), words...)
+		if err == nil {
+			consumeSearches("rh", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search rhymes' for input (%s), error: %w", args.opSearchRhymes, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllAnagramsOf(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchAnagrams, func(words []string) error {
+		searches, err := lxc.GetAllAnagramsOfContext(ctx, searchOptions(), words...)
+		if err == nil {
+			consumeSearches("sa", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search anagrams' for input (%s), error: %w", args.opSearchAnagrams, err)
+	}
+
+	return nil
+}
+
+func tryOperateGetAllWordsFromTiles(ctx context.Context, lxc lexicon.Lexicon) error {
+	err := forEachChunk(args.opSearchTiles, func(tiles []string) error {
+		searches, err := lxc.GetAllWordsFromTilesContext(ctx, searchOptions(), tiles...)
+		if err == nil {
+			consumeSearches("st", searches)
+		}
+		return err
+	})
+
+	if err != nil && !errors.Is(err, io.ErrNoInputValue) {
+		return fmt.Errorf("could not perform 'search tiles' for input (%s), error: %w", args.opSearchTiles, err)
+	}
+
+	return nil
+}
+
+// tryOperateSpellCheck reads the text of the file, or the standard input, and reports the words which do not exist in
+// the lexicon along with their position and suggestions. The text is checked chunk by chunk.
+func tryOperateSpellCheck(ctx context.Context, lxc lexicon.Lexicon) error {
+	if len(args.opSpellCheck) == 0 {
+		return nil
+	}
+
+	file := os.Stdin
+	if args.opSpellCheck != io.StdinValue {
+		var err error
+		if file, err = os.Open(args.opSpellCheck); err != nil {
+			return fmt.Errorf("could not perform 'spell check' from file (%s), error: %w", args.opSpellCheck, err)
+		}
+		defer file.Close()
+	}
+
+	unknown := 0
+	tokens := make([]tokenizer.Token, 0, chunkSize)
+	check := func() error {
+		words := make([]string, len(tokens))
+		for i, token := range tokens {
+			words[i] = token.Word
+		}
+
+		suggestions, err := lxc.SuggestContext(ctx, args.suggestions, words...)
+		if err != nil {
+			return err
+		}
+
+		misspellings := make([]io.Misspelling, 0)
+		for _, token := range tokens {
+			if suggested, ok := (*suggestions)[token.Word]; ok {
+				misspellings = append(misspellings, io.Misspelling{Word: token.Word, Line: token.Line, Column: token.Column, Suggestions: suggested})
+			}
+		}
+		outputPrinter.ConsumeMisspellings("sk", &misspellings)
+
+		unknown += len(misspellings)
+		tokens = tokens[:0]
+		return nil
+	}
+
+	err := tokenizer.ScanTokens(file, func(token tokenizer.Token) error {
+		if tokens = append(tokens, token); len(tokens) == chunkSize {
+			return check()
+		}
+		return nil
+	})
+	if err == nil && len(tokens) != 0 {
+		err = check()
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not perform 'spell check' from file (%s), error: %w", args.opSpellCheck, err)
+	}
+
+	log.Printf("spell check completed, %d unknown words\n", unknown)
+	return nil
 }
 
-func tryOperateAdd(lxc lexicon.Lexicon) {
-	words, err := wordSupplier.Get(args.opAdd)
-	if len(words) == 0 || errors.Is(io.ErrNoInputValue, err) {
-		return // this operation was not selected
+// tryOperateAdd adds the input words chunk by chunk, the lexicon adds all the chunks at once if it is configured to add
+// atomically.
+func tryOperateAdd(ctx context.Context, lxc lexicon.Lexicon) error {
+	next, closeInput, err := chunks(args.opAdd)
+	if errors.Is(err, io.ErrNoInputValue) {
+		return nil // this operation was not selected
 	} else if err != nil {
-		log.Printf("could not perform 'add' for input (%s), error: %s\n", args.opAdd, err.Error())
+		return fmt.Errorf("could not perform 'add' from file (%s), error: %w", args.opAdd, err)
 	}
+	defer closeInput()
 
-	if err = lxc.Add(words...); err != nil {
-		log.Fatalf("could not perform 'add' from file (%s), error: %s\n", args.opAdd, err.Error())
-	} else {
-		fmt.Println("add operation completed")
+	rejected := 0
+	err = lxc.AddStreamContext(ctx, next, func(result *lexicon.AddResult) {
+		outputPrinter.ConsumeAddResult("ad", result)
+		rejected += len(result.Rejected)
+	}, func(done, total int) {
+		log.Printf("add: %d of %d words processed\n", done, total)
+	})
+	if err != nil {
+		return fmt.Errorf("could not perform 'add' from file (%s), error: %w", args.opAdd, err)
+	}
+
+	log.Printf("add operation completed, %d words rejected\n", rejected)
+	return nil
+}
+
+func tryOperateRemove(ctx context.Context, lxc lexicon.Lexicon) error {
+	removed := 0
+	err := forEachChunk(args.opRemove, func(words []string) error {
+		count, err := lxc.RemoveContext(ctx, words...)
+		removed += count
+		return err
+	})
+
+	if errors.Is(err, io.ErrNoInputValue) {
+		return nil // this operation was not selected
+	} else if err != nil {
+		return fmt.Errorf("could not perform 'remove' from file (%s), error: %w", args.opRemove, err)
 	}
+
+	log.Printf("remove operation completed, %d words removed\n", removed)
+	return nil
 }
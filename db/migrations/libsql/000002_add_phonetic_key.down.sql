@@ -0,0 +1,3 @@
+-- delete the phonetic key, the index must be dropped before the column
+drop index if exists lexicon_phonetic_idx;
+alter table lexicon drop column phonetic;
@@ -0,0 +1,4 @@
+-- phonetic key of the word, words added before the column existed are filled by the lexicon on the
+-- first phonetic search, keys are compared exactly
+alter table lexicon add column phonetic varchar(100);
+create index if not exists lexicon_phonetic_idx on lexicon (phonetic);
@@ -0,0 +1,3 @@
+-- delete the anagram key, the index must be dropped before the column
+drop index if exists lexicon_anagram_idx;
+alter table lexicon drop column anagram;
@@ -0,0 +1,4 @@
+-- anagram key of the word, i.e. its sorted aksharas, words added before the column existed are filled by
+-- the lexicon on the first anagram search, keys are compared exactly
+alter table lexicon add column anagram varchar(200);
+create index if not exists lexicon_anagram_idx on lexicon (anagram);
@@ -0,0 +1,3 @@
+-- delete the akshara count, the index must be dropped before the column
+drop index if exists lexicon_aksharas_idx;
+alter table lexicon drop column aksharas;
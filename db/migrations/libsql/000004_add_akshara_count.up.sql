@@ -0,0 +1,4 @@
+-- count of aksharas of the word, words added before the column existed are filled by the lexicon on the
+-- first search by count
+alter table lexicon add column aksharas int;
+create index if not exists lexicon_aksharas_idx on lexicon (aksharas);
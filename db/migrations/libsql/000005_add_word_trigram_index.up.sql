@@ -0,0 +1,2 @@
+-- no-op, SQLite has no trigram index so LIKE '%infix%' scans every word, the version keeps the migrations of every
+-- dialect numbered alike
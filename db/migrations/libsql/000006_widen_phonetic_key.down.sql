@@ -0,0 +1 @@
+-- no-op, see the up migration
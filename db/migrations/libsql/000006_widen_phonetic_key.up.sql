@@ -0,0 +1,2 @@
+-- no-op, SQLite does not enforce the length of varchar so every phonetic key already fits, the version keeps the
+-- migrations of every dialect numbered alike
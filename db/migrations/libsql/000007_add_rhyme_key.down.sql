@@ -0,0 +1,3 @@
+-- delete the rhyme key, the index must be dropped before the column
+drop index if exists lexicon_rhyme_idx;
+alter table lexicon drop column rhyme;
@@ -0,0 +1,3 @@
+-- delete the collation key, the index must be dropped before the column
+drop index if exists lexicon_sort_key_idx;
+alter table lexicon drop column sort_key;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete the phonetic key, drops the index along with it
+alter table lexicon drop column phonetic;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+-- phonetic key of the word, words added before the column existed are filled by the lexicon on the
+-- first phonetic search, keys are compared exactly
+alter table lexicon add column phonetic varchar(100) character set utf8 collate utf8_bin;
+create index lexicon_phonetic_idx on lexicon (phonetic);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete the anagram key, drops the index along with it
+alter table lexicon drop column anagram;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+-- anagram key of the word, i.e. its sorted aksharas, words added before the column existed are filled by
+-- the lexicon on the first anagram search, keys are compared exactly
+alter table lexicon add column anagram varchar(200) character set utf8 collate utf8_bin;
+create index lexicon_anagram_idx on lexicon (anagram);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete the akshara count, drops the index along with it
+alter table lexicon drop column aksharas;
+
+commit;
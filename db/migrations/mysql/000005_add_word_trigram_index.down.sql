@@ -0,0 +1,5 @@
+begin;
+
+-- no-op, see the up migration
+
+commit;
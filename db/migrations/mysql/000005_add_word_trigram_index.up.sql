@@ -0,0 +1,6 @@
+begin;
+
+-- no-op, MySQL has no trigram index so LIKE '%infix%' scans every word, the version keeps the migrations of every
+-- dialect numbered alike
+
+commit;
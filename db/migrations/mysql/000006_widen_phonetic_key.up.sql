@@ -0,0 +1,7 @@
+begin;
+
+-- phonetic key is longer than the word when it has ऋ, ॠ or their vowel signs as they are written as रि,
+-- twice the maximum word length fits every key
+alter table lexicon modify phonetic varchar(200) character set utf8 collate utf8_bin;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+-- collation key of the word, the searches order the words by it in the dictionary order, words added before the column
+-- existed are filled by the lexicon on the first such search, keys are compared by their bytes
+alter table lexicon add column sort_key varchar(700) character set utf8 collate utf8_bin;
+create index lexicon_sort_key_idx on lexicon (sort_key);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete table, drops the index along with it
+drop table if exists lexicon;
+
+commit;
@@ -0,0 +1,13 @@
+begin;
+
+-- create table lexicon
+create table if not exists lexicon(
+    word varchar(100) not null,
+    primary key (word)
+);
+
+-- primary key index follows the DB collation which LIKE 'prefix%' cannot use unless the collation is C,
+-- text_pattern_ops compares character by character so it serves the prefix searches
+create index if not exists lexicon_word_pattern_idx on lexicon (word text_pattern_ops);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete the trigram index, the extension is left in place as other tables may use it
+drop index if exists lexicon_word_trgm_idx;
+
+commit;
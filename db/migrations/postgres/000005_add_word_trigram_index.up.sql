@@ -0,0 +1,8 @@
+begin;
+
+-- trigrams of the words let LIKE '%infix%' use an index instead of scanning every word, the pg_trgm
+-- extension is part of the standard contrib modules but creating it needs the CREATE privilege on the DB
+create extension if not exists pg_trgm;
+create index if not exists lexicon_word_trgm_idx on lexicon using gin (word gin_trgm_ops);
+
+commit;
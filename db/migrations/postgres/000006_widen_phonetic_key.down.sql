@@ -0,0 +1,7 @@
+begin;
+
+-- keys which do not fit are cleared, the lexicon fills them again on the next phonetic search
+update lexicon set phonetic = null where char_length(phonetic) > 100;
+alter table lexicon alter column phonetic type varchar(100);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete the rhyme key, drops the index along with it
+alter table lexicon drop column if exists rhyme;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+-- rhyme key of the word, i.e. the vowels of its aksharas from the last one, words added before the column existed
+-- are filled by the lexicon on the first rhyme search, keys are compared by their code points
+alter table lexicon add column if not exists rhyme varchar(200) collate "C";
+create index if not exists lexicon_rhyme_idx on lexicon (rhyme);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- delete the collation key, drops the index along with it
+alter table lexicon drop column if exists sort_key;
+
+commit;
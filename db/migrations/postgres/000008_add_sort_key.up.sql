@@ -0,0 +1,8 @@
+begin;
+
+-- collation key of the word, the searches order the words by it in the dictionary order, words added before the column
+-- existed are filled by the lexicon on the first such search, keys are compared by their bytes
+alter table lexicon add column if not exists sort_key varchar(700) collate "C";
+create index if not exists lexicon_sort_key_idx on lexicon (sort_key);
+
+commit;
@@ -6,8 +6,11 @@ toolchain go1.21.2
 
 require (
 	github.com/go-sql-driver/mysql v1.7.1
+	github.com/lib/pq v1.10.9
 	github.com/libsql/libsql-client-go v0.0.0-20231116123136-ff4e46c3d3a1
 	github.com/testcontainers/testcontainers-go v0.26.0
+	golang.org/x/term v0.13.0
+	golang.org/x/text v0.13.0
 )
 
 require (
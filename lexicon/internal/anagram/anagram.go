@@ -0,0 +1,68 @@
+// Package anagram keys words by the multiset of their aksharas.
+//
+// Words made of the same aksharas, in any order, have the same key, e.g. कमल & मकल. The key is the sorted
+// aksharas of the word joined by a separator, so it can be stored along with the word and looked up exactly.
+// Words which can be formed from a part of a set of tiles are found through the keys of every sub-multiset
+// of the tiles.
+package anagram
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+const (
+	// separator separates the aksharas of a key, it never occurs in a word.
+	separator = "\x1f"
+)
+
+// Key returns the anagram key of the word, words made of the same aksharas have the same key.
+func Key(word string) string {
+	aksharas := akshara.Split(word)
+	sort.Strings(aksharas)
+
+	return strings.Join(aksharas, separator)
+}
+
+// Keys returns the anagram keys of every non empty sub-multiset of the aksharas of the tiles, i.e. the keys of all
+// the words which can be formed using some of the tiles, every tile at most once. Keys are in no particular order.
+// The count of keys grows exponentially with the count of distinct tiles.
+func Keys(tiles string) []string {
+	aksharas := akshara.Split(tiles)
+	sort.Strings(aksharas)
+
+	// distinct aksharas along with their count, a sub-multiset has up to `count` of each of them
+	distinct, counts := make([]string, 0, len(aksharas)), make([]int, 0, len(aksharas))
+	for i, a := range aksharas {
+		if i > 0 && aksharas[i-1] == a {
+			counts[len(counts)-1]++
+		} else {
+			distinct, counts = append(distinct, a), append(counts, 1)
+		}
+	}
+
+	keys := make([]string, 0)
+	chosen := make([]string, 0, len(aksharas))
+	var choose func(i int)
+	choose = func(i int) {
+		if i == len(distinct) {
+			if len(chosen) != 0 {
+				keys = append(keys, strings.Join(chosen, separator))
+			}
+			return
+		}
+
+		size := len(chosen)
+		choose(i + 1)
+		for n := 1; n <= counts[i]; n++ {
+			chosen = append(chosen, distinct[i])
+			choose(i + 1)
+		}
+		chosen = chosen[:size]
+	}
+	choose(0)
+
+	return keys
+}
@@ -0,0 +1,36 @@
+package anagram
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	if Key("कमल") != Key("मकल") {
+		t.Errorf("Key(%q) = %q, Key(%q) = %q, want equal", "कमल", Key("कमल"), "मकल", Key("मकल"))
+	}
+	if Key("नमस्कार") == Key("नमसकार") {
+		t.Errorf("Key(%q) = Key(%q), want conjunct to be a single akshara", "नमस्कार", "नमसकार")
+	}
+}
+
+func TestKeys(t *testing.T) {
+	// sub-multisets of {क, क, म} are {क}, {म}, {क, क}, {क, म} & {क, क, म}
+	got := Keys("ककम")
+	want := []string{Key("क"), Key("म"), Key("कक"), Key("कम"), Key("ककम")}
+	sort.Strings(got)
+	sort.Strings(want)
+
+	if len(got) != len(want) {
+		t.Fatalf("Keys() = %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("Keys() = %q, want %q", got, want)
+		}
+	}
+
+	if got := Keys(""); len(got) != 0 {
+		t.Errorf("Keys() = %q, want none", got)
+	}
+}
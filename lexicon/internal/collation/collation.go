@@ -0,0 +1,205 @@
+// Package collation orders Devanagari words as a Marathi or Hindi dictionary does, following the varnamala.
+//
+// Words are compared akshara by akshara, first by the consonant and then by the vowel of the akshara
+//   - consonants are ordered क ख ग घ ङ च छ ज झ ञ ट ठ ड ढ ण त थ द ध न प फ ब भ म य र ल व श ष स ह ळ
+//   - vowels are ordered अ आ इ ई उ ऊ ऋ ॠ ऌ ॡ ऍ ऎ ए ऐ ऑ ऒ ओ औ अं अः, a vowel sign sorts as its vowel and a consonant
+//     without one has the inherent अ, so a consonant is followed by its barakhadi क का कि ... कौ कं कः
+//   - a virama sorts after all the vowels, so the conjuncts starting with a consonant follow its barakhadi, क्ष and
+//     ज्ञ are conjuncts of क and ज
+//   - anusvara, candrabindu & visarga after a vowel sign sort right after the vowel sign, e.g. का कां काः काक
+//
+// Nukta forms sort as their consonants and the zero width joiners are ignored, words which are the same otherwise
+// are ordered by their code points. Digits sort before the letters and the characters of other scripts after them.
+package collation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+const (
+	// weights of the kinds of characters, a character weighs the weight of its kind along with its place in the kind
+	weightDigit     = 1
+	weightModifier  = 15
+	weightVowel     = 20
+	weightConsonant = 100
+	weightOther     = 1 << 21 // beyond every code point
+)
+
+var (
+	// vowels in the order of the varnamala, along with their vowel signs, अ has no sign
+	vowels = []rune("अआइईउऊऋॠऌॡऍऎएऐऑऒओऔ")
+	signs  = []rune("\x00\u093E\u093F\u0940\u0941\u0942\u0943\u0944\u0962\u0963\u0945\u0946\u0947\u0948\u0949\u094A\u094B\u094C")
+
+	// weights of अ, of अं & अः which follow औ, and of virama which follows all of them
+	weightA      = weightVowel
+	weightAm     = weightVowel + len(vowels)
+	weightAh     = weightVowel + len(vowels) + 1
+	weightVirama = weightVowel + len(vowels) + 2
+
+	consonants = []rune("कखगघङचछजझञटठडढणतथदधनपफबभमयरलवशषसहळ")
+	modifiers  = []rune{akshara.Candrabindu, akshara.Anusvara, akshara.Visarga, 'ऽ'}
+
+	// variants are the letters which sort as another letter, i.e. the nukta forms and the Marathi candra अ
+	variants = map[rune]rune{
+		'\u0958': 'क', '\u0959': 'ख', '\u095A': 'ग', '\u095B': 'ज', // precomposed nukta letters क़ ख़ ग़ ज़
+		'\u095C': 'ड', '\u095D': 'ढ', '\u095E': 'फ', '\u095F': 'य', // precomposed nukta letters ड़ ढ़ फ़ य़
+		'\u0929': 'न', '\u0931': 'र', '\u0934': 'ळ', // precomposed nukta letters ऩ ऱ ऴ
+		'\u0972': 'ऍ', // Marathi candra अ
+	}
+
+	// weights of the letters, digits & signs
+	weights = make(map[rune]int)
+)
+
+func init() {
+	for i, r := range vowels {
+		weights[r] = weightVowel + i
+		if signs[i] != 0 {
+			weights[signs[i]] = weightVowel + i
+		}
+	}
+	for i, r := range consonants {
+		weights[r] = weightConsonant + i
+	}
+	for i, r := range modifiers {
+		weights[r] = weightModifier + i
+	}
+	for i := 0; i < 10; i++ {
+		weights['0'+rune(i)] = weightDigit + i
+		weights['०'+rune(i)] = weightDigit + i
+	}
+	weights[akshara.Virama] = weightVirama
+}
+
+// Key returns the collation key of the word, words are in dictionary order when their keys are in order.
+// Words which are the same except for nukta & joiners have the same key.
+func Key(word string) []int {
+	runes := letters(word)
+
+	key := make([]int, 0, 2*len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		w, ok := weights[r]
+		if !ok {
+			key = append(key, weightOther+int(r))
+			continue
+		} else if w < weightConsonant {
+			if r == 'अ' {
+				key, i = appendA(key, runes, i)
+			} else {
+				key = append(key, w)
+			}
+			continue
+		}
+
+		// a consonant is followed by its vowel sign or virama, else it has the inherent अ
+		key = append(key, w)
+		if i+1 < len(runes) && (isSign(runes[i+1]) || runes[i+1] == akshara.Virama) {
+			key = append(key, weights[runes[i+1]])
+			i++
+		} else {
+			key, i = appendA(key, runes, i)
+		}
+	}
+
+	return key
+}
+
+// SortKey returns the collation key of the word as a string, words are in dictionary order when their sort keys are
+// in the order of their bytes, e.g. of a binary collation of a database. Every weight of the key is written in hex,
+// weights below 0xf0 in 2 digits and the others as "f" followed by 6 digits, so a key is at most 7 times as long as
+// the word. Words which are the same except for nukta & joiners have the same sort key.
+func SortKey(word string) string {
+	var sb strings.Builder
+	for _, w := range Key(word) {
+		if w < 0xf0 {
+			fmt.Fprintf(&sb, "%02x", w)
+		} else {
+			fmt.Fprintf(&sb, "f%06x", w)
+		}
+	}
+
+	return sb.String()
+}
+
+// Compare returns an integer comparing two words in dictionary order, 0 if a == b, -1 if a < b, and +1 if a > b.
+func Compare(a, b string) int {
+	if c := slices.Compare(Key(a), Key(b)); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a, b)
+}
+
+// Sort sorts the words in dictionary order.
+func Sort(words []string) {
+	keys := make([][]int, len(words))
+	for i, word := range words {
+		keys[i] = Key(word)
+	}
+
+	sort.Sort(&byKey{words: words, keys: keys})
+}
+
+// appendA appends the weight of the अ ending at runes[i], अ followed by anusvara or candrabindu sorts as अं and
+// followed by visarga as अः. It returns the key and the index of the last rune of the vowel.
+func appendA(key []int, runes []rune, i int) ([]int, int) {
+	if i+1 < len(runes) {
+		switch runes[i+1] {
+		case akshara.Anusvara, akshara.Candrabindu:
+			return append(key, weightAm), i + 1
+		case akshara.Visarga:
+			return append(key, weightAh), i + 1
+		}
+	}
+
+	return append(key, weightA), i
+}
+
+// letters returns the runes of the word, variants replaced by the letters they sort as and nukta & joiners dropped.
+func letters(word string) []rune {
+	runes := make([]rune, 0, len(word))
+	for _, r := range word {
+		if r == akshara.Nukta || r == akshara.ZWNJ || r == akshara.ZWJ {
+			continue
+		} else if letter, ok := variants[r]; ok {
+			r = letter
+		}
+		runes = append(runes, r)
+	}
+
+	return runes
+}
+
+// isSign checks if the rune is a vowel sign.
+func isSign(r rune) bool {
+	return r != 0 && slices.Contains(signs, r)
+}
+
+// byKey sorts the words by their collation keys.
+type byKey struct {
+	words []string
+	keys  [][]int
+}
+
+func (s *byKey) Len() int {
+	return len(s.words)
+}
+
+func (s *byKey) Less(i, j int) bool {
+	if c := slices.Compare(s.keys[i], s.keys[j]); c != 0 {
+		return c < 0
+	}
+
+	return s.words[i] < s.words[j]
+}
+
+func (s *byKey) Swap(i, j int) {
+	s.words[i], s.words[j] = s.words[j], s.words[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
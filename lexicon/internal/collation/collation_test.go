@@ -0,0 +1,88 @@
+package collation
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{
+			name:  "Given words starting with vowels and consonants, when Sort is invoked, then vowels are expected before consonants in the order of varnamala",
+			words: []string{"कमल", "औषध", "अंक", "आई", "ळ", "इमारत", "अब", "हत्ती", "ऋषी", "घर"},
+			want:  []string{"अब", "आई", "इमारत", "ऋषी", "औषध", "अंक", "कमल", "घर", "हत्ती", "ळ"},
+		},
+		{
+			name:  "Given words of the barakhadi of a consonant, when Sort is invoked, then the order of barakhadi is expected",
+			words: []string{"कः", "कं", "कौ", "को", "कै", "के", "कृ", "कू", "कु", "की", "कि", "का", "क"},
+			want:  []string{"क", "का", "कि", "की", "कु", "कू", "कृ", "के", "कै", "को", "कौ", "कं", "कः"},
+		},
+		{
+			name:  "Given words with conjuncts, when Sort is invoked, then conjuncts are expected after the barakhadi of their first consonant",
+			words: []string{"क्षमा", "कौल", "कंस", "कमळ", "क्रम", "खग"},
+			want:  []string{"कमळ", "कौल", "कंस", "क्रम", "क्षमा", "खग"},
+		},
+		{
+			name:  "Given words with anusvara after a vowel sign, when Sort is invoked, then anusvara is expected right after the vowel sign",
+			words: []string{"काक", "कांत", "का", "काई"},
+			want:  []string{"का", "कांत", "काई", "काक"},
+		},
+		{
+			name:  "Given words with nukta and digits, when Sort is invoked, then nukta forms are expected with their consonants and digits first",
+			words: []string{"\u091C\u093Cमीन", "जमीन", "जल", "१२", "2"},
+			want:  []string{"१२", "2", "जमीन", "\u091C\u093Cमीन", "जल"},
+		},
+		{
+			name:  "Given words with anusvara on the inherent a, when Sort is invoked, then anusvara is expected after all the vowel signs unlike codepoint order",
+			words: []string{"संत", "सत", "साप", "सौर"},
+			want:  []string{"सत", "साप", "सौर", "संत"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := append([]string(nil), tt.words...)
+			if Sort(got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	if got := Compare("कमल", "कमल"); got != 0 {
+		t.Errorf("Compare() = %v, want 0 for same words", got)
+	}
+	if got := Compare("\u0958लम", "\u0915\u093Cलम"); got == 0 {
+		t.Errorf("Compare() = %v, want precomposed & decomposed nukta to be ordered apart", got)
+	}
+	if got := Compare("कमल", "क\u200Dमल"); got >= 0 {
+		t.Errorf("Compare() = %v, want word with joiner after the word without it", got)
+	}
+	if got := Compare("\u0958लम", "कमल"); got <= 0 {
+		t.Errorf("Compare() = %v, want nukta form to sort as its consonant", got)
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	words := []string{"", "क", "का", "कं", "क्ष", "खग", "अ", "अंक", "औषध", "१२", "2", "\u091C\u093Cमीन", "जमीन", "जल", "abc", "ab", "ब", "𑀓", "कa", "क𑀓"}
+
+	for _, a := range words {
+		for _, b := range words {
+			want := slices.Compare(Key(a), Key(b))
+			if got := strings.Compare(SortKey(a), SortKey(b)); got != want {
+				t.Errorf("SortKey() of %q & %q compare to %v, want %v as per their keys", a, b, got, want)
+			}
+		}
+	}
+
+	if got, want := len(SortKey(strings.Repeat("a", 100))), 700; got != want {
+		t.Errorf("len(SortKey()) = %v, want %v for a word of 100 letters of other scripts", got, want)
+	}
+}
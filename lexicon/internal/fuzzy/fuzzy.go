@@ -0,0 +1,165 @@
+// Package fuzzy finds the words similar to a given word.
+//
+// Similarity is the Damerau-Levenshtein distance computed over aksharas rather than characters, so a wrong
+// vowel sign, a missing akshara or two swapped aksharas are each one edit away, e.g. कार and कीर are at
+// distance 1. Words are held in a BK-tree which only visits the words that can be within the distance.
+package fuzzy
+
+import (
+	"sort"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+// A Match is a word found by a search along with its distance from the searched word.
+type Match struct {
+	Word     string
+	Distance int
+}
+
+// An Index is a BK-tree of words, it is not safe for concurrent use.
+// Removed words are only marked as removed as the tree cannot be restructured.
+// Words are also grouped by their folded form for the suggestions, see Fold.
+type Index struct {
+	root   *node
+	folded map[string]map[string]struct{} // words keyed by their folded form
+}
+
+type node struct {
+	word     string
+	aksharas []string
+	removed  bool
+	children map[int]*node // children keyed by their distance from this node
+}
+
+// New returns an empty Index.
+func New() *Index {
+	return &Index{folded: make(map[string]map[string]struct{})}
+}
+
+// Insert adds the word to the index, adding an existing word has no effect.
+func (idx *Index) Insert(word string) {
+	key := Fold(word)
+	if idx.folded[key] == nil {
+		idx.folded[key] = make(map[string]struct{})
+	}
+	idx.folded[key][word] = struct{}{}
+
+	aksharas := akshara.Split(word)
+	if idx.root == nil {
+		idx.root = &node{word: word, aksharas: aksharas}
+		return
+	}
+
+	n := idx.root
+	for {
+		d := Distance(n.aksharas, aksharas)
+		if d == 0 && n.word == word {
+			n.removed = false
+			return
+		}
+
+		child, ok := n.children[d]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[int]*node)
+			}
+			n.children[d] = &node{word: word, aksharas: aksharas}
+			return
+		}
+		n = child
+	}
+}
+
+// Remove deletes the word from the index, removing a word which is not present has no effect.
+func (idx *Index) Remove(word string) {
+	key := Fold(word)
+	if delete(idx.folded[key], word); len(idx.folded[key]) == 0 {
+		delete(idx.folded, key)
+	}
+
+	aksharas := akshara.Split(word)
+	for n := idx.root; n != nil; {
+		d := Distance(n.aksharas, aksharas)
+		if d == 0 && n.word == word {
+			n.removed = true
+			return
+		}
+		n = n.children[d]
+	}
+}
+
+// Search returns the words within `maxDistance` of the word ordered by their distance, words at the same
+// distance are ordered by code point.
+func (idx *Index) Search(word string, maxDistance int) []Match {
+	aksharas := akshara.Split(word)
+	matches := make([]Match, 0)
+
+	pending := make([]*node, 0)
+	if idx.root != nil {
+		pending = append(pending, idx.root)
+	}
+	for len(pending) != 0 {
+		n := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		d := Distance(n.aksharas, aksharas)
+		if d <= maxDistance && !n.removed {
+			matches = append(matches, Match{Word: n.word, Distance: d})
+		}
+
+		// by triangle inequality only the children within `maxDistance` of `d` can have matches
+		for childDistance, child := range n.children {
+			if childDistance >= d-maxDistance && childDistance <= d+maxDistance {
+				pending = append(pending, child)
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Word < matches[j].Word
+	})
+
+	return matches
+}
+
+// Distance returns the count of akshara insertions, deletions, substitutions and transpositions of adjacent
+// aksharas needed to change `a` into `b`, the transposed aksharas may be edited further which keeps Distance a
+// metric as the BK-tree requires, e.g. गक is 2 edits away from कखग.
+func Distance(a, b []string) int {
+	// edit matrix shifted by one, its first row and column hold a distance larger than any other
+	infinity := len(a) + len(b)
+	d := make([][]int, len(a)+2)
+	for i := range d {
+		d[i] = make([]int, len(b)+2)
+		d[i][0] = infinity
+		if i > 0 {
+			d[i][1] = i - 1
+		}
+	}
+	for j := 1; j < len(b)+2; j++ {
+		d[0][j] = infinity
+		d[1][j] = j - 1
+	}
+
+	lastRow := make(map[string]int) // last row of `a` having each akshara
+	for i := 1; i <= len(a); i++ {
+		lastColumn := 0 // last column of `b` matching the current akshara of `a`
+		for j := 1; j <= len(b); j++ {
+			k, l := lastRow[b[j-1]], lastColumn
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+				lastColumn = j
+			}
+
+			d[i+1][j+1] = min(d[i][j]+cost, d[i+1][j]+1, d[i][j+1]+1, d[k][l]+(i-k-1)+1+(j-l-1))
+		}
+		lastRow[a[i-1]] = i
+	}
+
+	return d[len(a)+1][len(b)+1]
+}
@@ -0,0 +1,164 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{
+			name: "Given words differing in a vowel sign, when Distance is invoked, then one substitution is expected",
+			a:    "कार",
+			b:    "कीर",
+			want: 1,
+		},
+		{
+			name: "Given words differing in a conjunct, when Distance is invoked, then the conjunct is one akshara",
+			a:    "नमस्कार",
+			b:    "नमकार",
+			want: 1,
+		},
+		{
+			name: "Given words with swapped aksharas, when Distance is invoked, then one transposition is expected",
+			a:    "कमल",
+			b:    "मकल",
+			want: 1,
+		},
+		{
+			name: "Given swapped aksharas with an akshara inserted between, when Distance is invoked, then two edits are expected",
+			a:    "गक",
+			b:    "कखग",
+			want: 2,
+		},
+		{
+			name: "Given a word and an empty word, when Distance is invoked, then the count of aksharas is expected",
+			a:    "मोक्ष",
+			b:    "",
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(akshara.Split(tt.a), akshara.Split(tt.b)); got != tt.want {
+				t.Errorf("Distance(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := New()
+	for _, word := range []string{"नमस्ते", "धन्यवाद", "नमस्कार", "सुंदर", "मोक्ष", "नमस्कर", "नमस्कार"} {
+		idx.Insert(word)
+	}
+	idx.Remove("नमस्ते")
+
+	want := []Match{{"नमस्कार", 0}, {"नमस्कर", 1}}
+	if got := idx.Search("नमस्कार", 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Index.Search() = %v, want %v", got, want)
+	}
+
+	want = []Match{{"नमस्कर", 1}, {"नमस्कार", 1}}
+	if got := idx.Search("नमस्कीर", 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Index.Search() = %v, want %v", got, want)
+	}
+
+	idx.Insert("नमस्ते")
+	if got := idx.Search("नमस्ते", 0); !reflect.DeepEqual(got, []Match{{"नमस्ते", 0}}) {
+		t.Errorf("Index.Search() after re-insert = %v, want %v", got, []Match{{"नमस्ते", 0}})
+	}
+}
+
+func TestIndex_SearchTransposition(t *testing.T) {
+	// the distances of transposed aksharas must obey the triangle inequality or the BK-tree misses words
+	for _, words := range [][]string{{"गक", "कखग"}, {"कखग", "गक"}} {
+		idx := New()
+		for _, word := range words {
+			idx.Insert(word)
+		}
+
+		want := []Match{{"कखग", 1}, {"गक", 1}}
+		if got := idx.Search("कग", 1); !reflect.DeepEqual(got, want) {
+			t.Errorf("Index.Search() with %v = %v, want %v", words, got, want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{
+			name: "Given words differing in half nasal and anusvara, when Fold is invoked, then folded forms are equal",
+			a:    "हिन्दी",
+			b:    "हिंदी",
+		},
+		{
+			name: "Given words differing in vowel length, when Fold is invoked, then folded forms are equal",
+			a:    "दीन",
+			b:    "दिन",
+		},
+		{
+			name: "Given words differing in sibilants, when Fold is invoked, then folded forms are equal",
+			a:    "विशेष",
+			b:    "विसेस",
+		},
+		{
+			name: "Given words differing in nukta, when Fold is invoked, then folded forms are equal",
+			a:    "ज़रूर",
+			b:    "जरुर",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Fold(tt.a) != Fold(tt.b) {
+				t.Errorf("Fold(%q) = %q, Fold(%q) = %q, want equal", tt.a, Fold(tt.a), tt.b, Fold(tt.b))
+			}
+		})
+	}
+}
+
+func TestIndex_Suggest(t *testing.T) {
+	idx := New()
+	for _, word := range []string{"हिंदी", "हिना", "दिन", "दान", "सुंदर", "मोक्ष"} {
+		idx.Insert(word)
+	}
+
+	// a difference in the confusion sets is preferred over a plain edit
+	want := []string{"दिन", "दान"}
+	if got := idx.Suggest("दीन", 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Index.Suggest() = %v, want %v", got, want)
+	}
+
+	// folded words are found even beyond the akshara distance
+	if got := idx.Suggest("हिन्दी", 1); !reflect.DeepEqual(got, []string{"हिंदी"}) {
+		t.Errorf("Index.Suggest() = %v, want %v", got, []string{"हिंदी"})
+	}
+
+	if got := idx.Suggest("कमल", 3); len(got) != 0 {
+		t.Errorf("Index.Suggest() = %v, want none", got)
+	}
+
+	if !idx.Contains("दिन") || idx.Contains("दीन") {
+		t.Errorf("Index.Contains() = %v, %v, want true, false", idx.Contains("दिन"), idx.Contains("दीन"))
+	}
+
+	// the transposition is found along with the words beyond its sibling in the tree
+	idx = New()
+	for _, word := range []string{"गक", "कममग"} {
+		idx.Insert(word)
+	}
+	if got := idx.Suggest("कग", 2); !reflect.DeepEqual(got, []string{"गक", "कममग"}) {
+		t.Errorf("Index.Suggest() = %v, want %v", got, []string{"गक", "कममग"})
+	}
+}
@@ -0,0 +1,107 @@
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+const (
+	// suggestDistance is the akshara distance within which the corrections of a word are looked for, words
+	// differing only in the confusion sets are found at any distance.
+	suggestDistance = 2
+)
+
+var (
+	// folder replaces the letters and signs which are commonly confused with each other by a single one of them.
+	folder = strings.NewReplacer(
+		"\u0908", "\u0907", "\u0940", "\u093F", // long & short i, ई & इ
+		"\u090A", "\u0909", "\u0942", "\u0941", // long & short u, ऊ & उ
+		"\u0936", "\u0938", "\u0937", "\u0938", // sibilants श, ष & स
+		"\u0901", "\u0902", // candrabindu & anusvara
+		"\u093C", "", // nukta
+		"\u0958", "\u0915", "\u0959", "\u0916", "\u095A", "\u0917", "\u095B", "\u091C", // precomposed nukta letters क़ ख़ ग़ ज़
+		"\u095C", "\u0921", "\u095D", "\u0922", "\u095E", "\u092B", "\u095F", "\u092F", // precomposed nukta letters ड़ ढ़ फ़ य़
+	)
+)
+
+// Fold returns the word with the commonly confused letters and signs replaced by a single one of them, words
+// which are spelled differently only within the confusion sets have the same folded form, e.g. हिन्दी & हिंदी.
+// Confusion sets are the short & long vowels इ/ई and उ/ऊ along with their vowel signs, the sibilants श/ष/स,
+// anusvara, candrabindu & the half nasal consonants, and presence of nukta.
+func Fold(word string) string {
+	// a half nasal consonant before another consonant is written as anusvara as well
+	return akshara.FoldNasals(folder.Replace(word))
+}
+
+// Contains checks if the word is present in the index.
+func (idx *Index) Contains(word string) bool {
+	aksharas := akshara.Split(word)
+	for n := idx.root; n != nil; {
+		d := Distance(n.aksharas, aksharas)
+		if d == 0 && n.word == word {
+			return !n.removed
+		}
+		n = n.children[d]
+	}
+
+	return false
+}
+
+// Suggest returns at most `n` words of the index which are the likely corrections of the word, the most likely first.
+// A difference within the confusion sets (see Fold) is more likely than any other edit, so words are ranked by
+// twice their distance after folding plus their plain distance, words with the same rank by their distance in
+// characters and then by code point.
+func (idx *Index) Suggest(word string, n int) []string {
+	candidates := make(map[string]struct{})
+	for _, match := range idx.Search(word, suggestDistance) {
+		candidates[match.Word] = struct{}{}
+	}
+
+	foldedWord := Fold(word)
+	for candidate := range idx.folded[foldedWord] {
+		candidates[candidate] = struct{}{}
+	}
+
+	type ranked struct {
+		word       string
+		rank       int
+		characters int
+	}
+	aksharas, foldedAksharas, chars := akshara.Split(word), akshara.Split(foldedWord), characters(word)
+	rankings := make([]ranked, 0, len(candidates))
+	for candidate := range candidates {
+		plain := Distance(aksharas, akshara.Split(candidate))
+		folded := Distance(foldedAksharas, akshara.Split(Fold(candidate)))
+		rankings = append(rankings, ranked{candidate, 2*folded + plain, Distance(chars, characters(candidate))})
+	}
+
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].rank != rankings[j].rank {
+			return rankings[i].rank < rankings[j].rank
+		}
+		if rankings[i].characters != rankings[j].characters {
+			return rankings[i].characters < rankings[j].characters
+		}
+		return rankings[i].word < rankings[j].word
+	})
+
+	suggestions := make([]string, 0, n)
+	for i := 0; i < len(rankings) && i < n; i++ {
+		suggestions = append(suggestions, rankings[i].word)
+	}
+
+	return suggestions
+}
+
+// characters returns every character of the word on its own, so that Distance counts the character edits.
+func characters(word string) []string {
+	runes := []rune(word)
+	chars := make([]string, len(runes))
+	for i, r := range runes {
+		chars[i] = string(r)
+	}
+
+	return chars
+}
@@ -0,0 +1,34 @@
+package lexicon
+
+import (
+	"sort"
+)
+
+// keyIndex groups the words by a key derived from them, e.g. their phonetic key.
+type keyIndex map[string]map[string]struct{}
+
+// insert adds the word to the group of the key.
+func (idx keyIndex) insert(key, word string) {
+	if idx[key] == nil {
+		idx[key] = make(map[string]struct{})
+	}
+	idx[key][word] = struct{}{}
+}
+
+// remove deletes the word from the group of the key, empty groups are dropped.
+func (idx keyIndex) remove(key, word string) {
+	if delete(idx[key], word); len(idx[key]) == 0 {
+		delete(idx, key)
+	}
+}
+
+// words returns the words of the key in lexicographical order.
+func (idx keyIndex) words(key string) []string {
+	words := make([]string, 0, len(idx[key]))
+	for word := range idx[key] {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	return words
+}
@@ -0,0 +1,729 @@
+package lexicon
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/anagram"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/fuzzy"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/pattern"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/phonetic"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/rhyme"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/types"
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+var (
+	errNilOrEmptyWords  = errors.New("list of words is nil or empty")
+	errNilRewrite       = errors.New("rewrite function is nil")
+	errNegativeDistance = errors.New("distance is negative")
+	errNoSuggestions    = errors.New("count of suggestions is less than 1")
+	errNoAksharas       = errors.New("count of aksharas is less than 1")
+)
+
+// Open returns an instance of LexiconMemory.
+// If `filePath` is not empty then the words are loaded from the file, words in the file are expected
+// to be whitespace delimited. A non existing file is not an error, it will be created on Close.
+// If the file exists but cannot be read then the function will panic.
+func Open(filePath string) *LexiconMemory {
+	lxc := &LexiconMemory{
+		filePath: filePath,
+		prefixes: newTrie(),
+		suffixes: newTrie(),
+		infixes:  newNgramIndex(),
+		similar:  fuzzy.New(),
+		sounds:   make(keyIndex),
+		anagrams: make(keyIndex),
+	}
+
+	if len(filePath) != 0 {
+		if err := lxc.load(); err != nil {
+			log.Panicln(err.Error())
+		}
+	}
+
+	return lxc
+}
+
+// LexiconMemory provides implementation of Lexicon which holds all the words in memory.
+// Words are stored in a trie for prefix searches and in a trie of reversed words for suffix searches,
+// so both of the searches only visit the matching words. Words containing a substring are found through
+// an index of the runes and bigrams of the words, similar words through a BK-tree, and words which sound
+// alike or are made of the same aksharas through their phonetic and anagram keys.
+// Optionally the words can be loaded from and saved to a file.
+type LexiconMemory struct {
+	mu       sync.RWMutex
+	filePath string       // file to load words from and save them to, empty if words are not persisted
+	dirty    bool         // true if words were added or removed since the lexicon was loaded
+	prefixes *trie        // trie of words
+	suffixes *trie        // trie of reversed words
+	infixes  *ngramIndex  // n-grams of words
+	similar  *fuzzy.Index // BK-tree of words
+	sounds   keyIndex     // words keyed by their phonetic key
+	anagrams keyIndex     // words keyed by their anagram key
+}
+
+func (lxc *LexiconMemory) Lookup(words ...string) (*[]string, error) {
+	return lxc.LookupContext(context.Background(), words...)
+}
+
+func (lxc *LexiconMemory) LookupContext(ctx context.Context, words ...string) (*[]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	exists := make([]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if lxc.prefixes.contains([]rune(word)) {
+			exists = append(exists, word)
+		}
+	}
+
+	return &exists, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsStartingWith(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsStartingWithContext(context.Background(), types.SearchOptions{}, substrings...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsStartingWithContext(ctx context.Context, options types.SearchOptions, substrings ...string) (*map[string][]string, error) {
+	if len(substrings) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, substring := range substrings {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		matches := lxc.prefixes.withPrefix([]rune(substring))
+		if len(matches) == 0 {
+			continue
+		}
+
+		words := make([]string, len(matches))
+		for i, match := range matches {
+			words[i] = string(match)
+		}
+		if words = options.Apply(words); len(words) != 0 {
+			result[substring] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsEndingWith(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsEndingWithContext(context.Background(), types.SearchOptions{}, substrings...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsEndingWithContext(ctx context.Context, options types.SearchOptions, substrings ...string) (*map[string][]string, error) {
+	if len(substrings) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, substring := range substrings {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		matches := lxc.suffixes.withPrefix(reversed([]rune(substring)))
+		if len(matches) == 0 {
+			continue
+		}
+
+		// matches are ordered on reversed words, restore the words and order them again
+		words := make([]string, len(matches))
+		for i, match := range matches {
+			words[i] = string(reversed(match))
+		}
+		sort.Strings(words)
+		if words = options.Apply(words); len(words) != 0 {
+			result[substring] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsContaining(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsContainingContext(context.Background(), types.SearchOptions{}, substrings...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsContainingContext(ctx context.Context, options types.SearchOptions, substrings ...string) (*map[string][]string, error) {
+	if len(substrings) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, substring := range substrings {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var words []string
+		if len(substring) == 0 {
+			// every word contains the empty string
+			words = make([]string, 0)
+			lxc.prefixes.root.walk(make([]rune, 0, 32), func(word []rune) {
+				words = append(words, string(word))
+			})
+		} else {
+			words = lxc.infixes.containing([]rune(substring))
+		}
+
+		if words = options.Apply(words); len(words) != 0 {
+			result[substring] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsOfAksharaCount(count int, prefixes ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsOfAksharaCountContext(context.Background(), types.SearchOptions{}, count, prefixes...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsOfAksharaCountContext(ctx context.Context, options types.SearchOptions, count int, prefixes ...string) (*map[string][]string, error) {
+	if len(prefixes) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if count < 1 {
+		return nil, errNoAksharas
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, prefix := range prefixes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		words := make([]string, 0)
+		for _, match := range lxc.prefixes.withPrefix([]rune(prefix)) {
+			if word := string(match); akshara.Count(word) == count {
+				words = append(words, word)
+			}
+		}
+
+		if words = options.Apply(words); len(words) != 0 {
+			result[prefix] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsMatching(patterns ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsMatchingContext(context.Background(), types.SearchOptions{}, patterns...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsMatchingContext(ctx context.Context, options types.SearchOptions, patterns ...string) (*map[string][]string, error) {
+	if len(patterns) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, text := range patterns {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// only the words starting with the literal text before the first wildcard can match
+		p := pattern.Compile(text)
+		words := make([]string, 0)
+		for _, match := range lxc.prefixes.withPrefix([]rune(p.Prefix())) {
+			if word := string(match); p.Match(word) {
+				words = append(words, word)
+			}
+		}
+
+		if words = options.Apply(words); len(words) != 0 {
+			result[text] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsMatchingRegexp(expressions ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsMatchingRegexpContext(context.Background(), types.SearchOptions{}, expressions...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsMatchingRegexpContext(ctx context.Context, options types.SearchOptions, expressions ...string) (*map[string][]string, error) {
+	if len(expressions) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	res, err := types.CompileAll(expressions)
+	if err != nil {
+		return nil, err
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	var tooMany error
+	for i, expression := range expressions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// every word is visited, words before the page are matched as well to find where the page starts
+		words := make([]string, 0)
+		lxc.prefixes.root.walk(make([]rune, 0, 32), func(runes []rune) {
+			if word := string(runes); res[i].MatchString(word) && options.Keep(word) {
+				words = append(words, word)
+			}
+		})
+
+		if words = options.Page(options.Sort(words)); len(words) > types.MaxRegexpMatches {
+			words = words[:types.MaxRegexpMatches]
+			tooMany = types.ErrTooManyMatches
+		}
+		if len(words) != 0 {
+			result[expression] = words
+		}
+	}
+
+	return &result, tooMany
+}
+
+func (lxc *LexiconMemory) GetAllWordsSimilarTo(maxDistance int, words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsSimilarToContext(context.Background(), types.SearchOptions{}, maxDistance, words...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsSimilarToContext(ctx context.Context, options types.SearchOptions, maxDistance int, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if maxDistance < 0 {
+		return nil, errNegativeDistance
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if matches := lxc.similar.Search(word, maxDistance); len(matches) != 0 {
+			similar, distances := make([]string, len(matches)), make([]int, len(matches))
+			for i, match := range matches {
+				similar[i], distances[i] = match.Word, match.Distance
+			}
+			similar, err := options.PageRanked(options.Filter(options.SortRanked(similar, distances)))
+			if err != nil {
+				return nil, err
+			} else if len(similar) != 0 {
+				result[word] = similar
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsSoundingLike(words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsSoundingLikeContext(context.Background(), types.SearchOptions{}, words...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsSoundingLikeContext(ctx context.Context, options types.SearchOptions, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if sounding := options.Apply(lxc.sounds.words(phonetic.Key(word))); len(sounding) != 0 {
+			result[word] = sounding
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsRhymingWith(syllables int, strictness rhyme.Strictness, words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsRhymingWithContext(context.Background(), types.SearchOptions{}, syllables, strictness, words...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsRhymingWithContext(ctx context.Context, options types.SearchOptions, syllables int, strictness rhyme.Strictness, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		r, err := rhyme.New(word, syllables, strictness)
+		if err != nil {
+			return nil, err
+		}
+
+		// only the words ending with the text common to all the rhymes can match
+		rhyming := make([]string, 0)
+		for _, match := range lxc.suffixes.withPrefix(reversed([]rune(r.Suffix()))) {
+			if candidate := string(reversed(match)); r.Match(candidate) && options.Keep(candidate) {
+				rhyming = append(rhyming, candidate)
+			}
+		}
+
+		// the words are stored in code point order
+		sort.Strings(rhyming)
+		if rhyming = options.Page(options.Sort(rhyming)); len(rhyming) != 0 {
+			result[word] = rhyming
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllAnagramsOf(words ...string) (*map[string][]string, error) {
+	return lxc.GetAllAnagramsOfContext(context.Background(), types.SearchOptions{}, words...)
+}
+
+func (lxc *LexiconMemory) GetAllAnagramsOfContext(ctx context.Context, options types.SearchOptions, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if anagrams := options.Apply(lxc.anagrams.words(anagram.Key(word))); len(anagrams) != 0 {
+			result[word] = anagrams
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) GetAllWordsFromTiles(tiles ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsFromTilesContext(context.Background(), types.SearchOptions{}, tiles...)
+}
+
+func (lxc *LexiconMemory) GetAllWordsFromTilesContext(ctx context.Context, options types.SearchOptions, tiles ...string) (*map[string][]string, error) {
+	if len(tiles) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if err := types.CheckTiles(tiles); err != nil {
+		return nil, err
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, t := range tiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// every word belongs to a single key, words of different keys are distinct
+		words := make([]string, 0)
+		for _, key := range anagram.Keys(t) {
+			words = append(words, lxc.anagrams.words(key)...)
+		}
+
+		// the words are stored in code point order
+		sort.Strings(words)
+		if words = options.Apply(words); len(words) != 0 {
+			result[t] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) Suggest(n int, words ...string) (*map[string][]string, error) {
+	return lxc.SuggestContext(context.Background(), n, words...)
+}
+
+func (lxc *LexiconMemory) SuggestContext(ctx context.Context, n int, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if n < 1 {
+		return nil, errNoSuggestions
+	}
+
+	lxc.mu.RLock()
+	defer lxc.mu.RUnlock()
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, ok := result[word]; !ok && !lxc.prefixes.contains([]rune(word)) {
+			result[word] = lxc.similar.Suggest(word, n)
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconMemory) Add(words ...string) (*types.AddResult, error) {
+	return lxc.AddContext(context.Background(), words...)
+}
+
+func (lxc *LexiconMemory) AddContext(ctx context.Context, words ...string) (*types.AddResult, error) {
+	return lxc.addAll(ctx, nil, words)
+}
+
+// addAll adds the words, see Add, the progress is reported once all of them are added.
+func (lxc *LexiconMemory) addAll(ctx context.Context, progress types.ProgressFunc, words []string) (*types.AddResult, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	// words are added all at once, a cancelled context only prevents the add from starting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	result, toAdd := types.PrepareAdd(words)
+	for _, word := range toAdd {
+		if lxc.add(word) {
+			result.Inserted = append(result.Inserted, word)
+		} else {
+			result.Existing = append(result.Existing, word)
+		}
+	}
+
+	// there are no batches in memory, all of the words are done at once
+	progress.Report(len(toAdd), len(toAdd))
+	return result, nil
+}
+
+func (lxc *LexiconMemory) AddStream(next func() ([]string, error), consume func(result *types.AddResult), progress types.ProgressFunc) error {
+	return lxc.AddStreamContext(context.Background(), next, consume, progress)
+}
+
+func (lxc *LexiconMemory) AddStreamContext(ctx context.Context, next func() ([]string, error), consume func(result *types.AddResult), progress types.ProgressFunc) error {
+	// chunks are added one after the other, there is no transaction to add all of them at once
+	return types.AddStream(ctx, lxc.addAll, next, consume, progress)
+}
+
+// add inserts the word in both the tries and the indexes, caller must hold the write lock.
+// It returns false if the word was already present.
+func (lxc *LexiconMemory) add(word string) bool {
+	runes := []rune(word)
+	if !lxc.prefixes.insert(runes) {
+		return false
+	}
+
+	lxc.suffixes.insert(reversed(runes))
+	lxc.infixes.insert(runes)
+	lxc.similar.Insert(word)
+	lxc.sounds.insert(phonetic.Key(word), word)
+	lxc.anagrams.insert(anagram.Key(word), word)
+	lxc.dirty = true
+	return true
+}
+
+// remove deletes the word from both the tries and the indexes, caller must hold the write lock.
+// It returns false if the word was not present.
+func (lxc *LexiconMemory) remove(word string) bool {
+	runes := []rune(word)
+	if !lxc.prefixes.remove(runes) {
+		return false
+	}
+
+	lxc.suffixes.remove(reversed(runes))
+	lxc.infixes.remove(runes)
+	lxc.similar.Remove(word)
+	lxc.sounds.remove(phonetic.Key(word), word)
+	lxc.anagrams.remove(anagram.Key(word), word)
+	lxc.dirty = true
+	return true
+}
+
+func (lxc *LexiconMemory) Remove(words ...string) (int, error) {
+	return lxc.RemoveContext(context.Background(), words...)
+}
+
+func (lxc *LexiconMemory) RemoveContext(ctx context.Context, words ...string) (int, error) {
+	if len(words) == 0 {
+		return 0, errNilOrEmptyWords
+	}
+
+	// words are removed all at once, a cancelled context only prevents the remove from starting
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	removed := 0
+	for _, word := range words {
+		if lxc.remove(word) {
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+func (lxc *LexiconMemory) Rewrite(fn func(word string) string) (int, error) {
+	return lxc.RewriteContext(context.Background(), fn)
+}
+
+func (lxc *LexiconMemory) RewriteContext(ctx context.Context, fn func(word string) string) (int, error) {
+	if fn == nil {
+		return 0, errNilRewrite
+	}
+
+	// words are rewritten all at once, a cancelled context only prevents the rewrite from starting
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	olds, news := make([]string, 0), make([]string, 0)
+	lxc.prefixes.root.walk(make([]rune, 0, 32), func(runes []rune) {
+		word := string(runes)
+		if rewritten := fn(word); rewritten != word {
+			olds, news = append(olds, word), append(news, rewritten)
+		}
+	})
+
+	// all the old forms are removed first as a new form may be the old form of another word
+	for _, word := range olds {
+		lxc.remove(word)
+	}
+	for _, word := range news {
+		if len(word) != 0 {
+			lxc.add(word)
+		}
+	}
+
+	return len(olds), nil
+}
+
+func (lxc *LexiconMemory) Close() {
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	if len(lxc.filePath) == 0 || !lxc.dirty {
+		return
+	}
+
+	if err := lxc.save(); err != nil {
+		log.Printf("could not save lexicon to file (%s), error: %s\n", lxc.filePath, err.Error())
+	}
+}
+
+// load reads the words from the file into the tries.
+func (lxc *LexiconMemory) load() error {
+	file, err := os.Open(lxc.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("memory: could not open lexicon file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		lxc.add(scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("memory: lexicon file contents are invalid or file is corrupt: %w", err)
+	}
+
+	lxc.dirty = false
+	return nil
+}
+
+// save writes all the words, one per line, to the file.
+// Words are first written to a temporary file which then replaces the original so that a failed
+// write does not corrupt the existing file.
+func (lxc *LexiconMemory) save() error {
+	tmpPath := lxc.filePath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("memory: could not create lexicon file: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	lxc.prefixes.root.walk(make([]rune, 0, 32), func(word []rune) {
+		writer.WriteString(string(word))
+		writer.WriteByte('\n')
+	})
+
+	if err = writer.Flush(); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("memory: could not write lexicon file: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, lxc.filePath); err != nil {
+		return fmt.Errorf("memory: could not replace lexicon file: %w", err)
+	}
+
+	lxc.dirty = false
+	return nil
+}
@@ -0,0 +1,1009 @@
+package lexicon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/rhyme"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/types"
+)
+
+var randomWordsInsertedOnInit = [...]string{"नमस्ते", "धन्यवाद", "नमस्कार", "सुंदर", "मोक्ष"}
+
+func getLexicon() *LexiconMemory {
+	lxc := Open("")
+	lxc.Add(randomWordsInsertedOnInit[:]...)
+
+	return lxc
+}
+
+func TestLexiconMemory_Lookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		want    *[]string
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when Lookup is invoked for the existing word, then the word should be returned",
+			words: []string{"नमस्ते"},
+			want:  &([]string{"नमस्ते"}),
+		},
+		{
+			name:  "Given a Lexicon with some words, when Lookup is invoked for a prefix of an existing word, then nothing should be returned",
+			words: []string{"नमस्"},
+			want:  &([]string{}),
+		},
+		{
+			name:  "Given a Lexicon with some words, when Lookup is invoked multiple words some exists and others don't, then only existing words should be returned",
+			words: []string{"नमस्कार", "notexists", "सुंदर", "धन्यवाद", "पराक्रम", "पानी"},
+			want:  &([]string{"नमस्कार", "सुंदर", "धन्यवाद"}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when Lookup is invoked for nil words array, then error is expected",
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().Lookup(tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.Lookup() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsStartingWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		substrings []string
+		want       *map[string][]string
+		wantErr    bool
+	}{
+		{
+			name:       "Given a Lexicon with some words, when SearchStartsWith is invoked for mix of existing & non existing word, then return all the words starting with the existing words sorted lexicographically",
+			substrings: []string{"नम", "somethingelse", "नमस्", "धन्य"},
+			want: &(map[string][]string{
+				"नम":   {"नमस्कार", "नमस्ते"},
+				"नमस्": {"नमस्कार", "नमस्ते"},
+				"धन्य": {"धन्यवाद"},
+			}),
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchStartsWith is invoked for non-existing word, then return no response for the substring",
+			substrings: []string{"क्र"},
+			want:       &map[string][]string{},
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchStartsWith is invoked for empty words array, then error is expected",
+			substrings: []string{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsStartingWith(tt.substrings...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsStartingWith() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsStartingWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsEndingWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		substrings []string
+		want       *map[string][]string
+		wantErr    bool
+	}{
+		{
+			name:       "Given a Lexicon with some words, when SearchEndsWith is invoked for mix of existing & non existing word, then return all the words ending with the existing words sorted lexicographically",
+			substrings: []string{"र", "somethingelse", "क्ष", "वाद"},
+			want: &(map[string][]string{
+				"र":   {"नमस्कार", "सुंदर"},
+				"क्ष": {"मोक्ष"},
+				"वाद": {"धन्यवाद"},
+			}),
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchEndsWith is invoked for nil words array, then error is expected",
+			substrings: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsEndingWith(tt.substrings...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsEndingWith() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsEndingWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsContaining(t *testing.T) {
+	tests := []struct {
+		name       string
+		substrings []string
+		want       *map[string][]string
+		wantErr    bool
+	}{
+		{
+			name:       "Given a Lexicon with some words, when SearchContaining is invoked for mix of existing & non existing word, then return all the words containing the existing words sorted lexicographically",
+			substrings: []string{"मस्", "somethingelse", "क्ष", "न", "्"},
+			want: &(map[string][]string{
+				"मस्": {"नमस्कार", "नमस्ते"},
+				"क्ष": {"मोक्ष"},
+				"न":   {"धन्यवाद", "नमस्कार", "नमस्ते"},
+				"्":   {"धन्यवाद", "नमस्कार", "नमस्ते", "मोक्ष"},
+			}),
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchContaining is invoked for a substring whose runes exist but not together, then return no response for the substring",
+			substrings: []string{"दर्"},
+			want:       &map[string][]string{},
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchContaining is invoked for nil words array, then error is expected",
+			substrings: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsContaining(tt.substrings...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsContaining() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsContaining() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsOfAksharaCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		prefixes []string
+		want     *map[string][]string
+		wantErr  bool
+	}{
+		{
+			name:     "Given a Lexicon with some words, when SearchAksharaCount is invoked for mix of existing & non existing prefix, then return the words with the count of aksharas sorted lexicographically",
+			count:    4,
+			prefixes: []string{"न", "ध", "स", "somethingelse"},
+			want: &(map[string][]string{
+				"न": {"नमस्कार"},
+				"ध": {"धन्यवाद"},
+			}),
+		},
+		{
+			name:     "Given a Lexicon with some words, when SearchAksharaCount is invoked for a count which no word has, then return no response for the prefix",
+			count:    5,
+			prefixes: []string{"न"},
+			want:     &map[string][]string{},
+		},
+		{
+			name:     "Given a Lexicon with some words, when SearchAksharaCount is invoked for count less than 1, then error is expected",
+			count:    0,
+			prefixes: []string{"न"},
+			wantErr:  true,
+		},
+		{
+			name:     "Given a Lexicon with some words, when SearchAksharaCount is invoked for nil words array, then error is expected",
+			count:    3,
+			prefixes: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsOfAksharaCount(tt.count, tt.prefixes...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsOfAksharaCount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsOfAksharaCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsMatching(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     *map[string][]string
+		wantErr  bool
+	}{
+		{
+			name:     "Given a Lexicon with some words, when SearchPattern is invoked for mix of matching & non matching patterns, then return all the words matching the patterns sorted lexicographically",
+			patterns: []string{"न?स्?ार", "न*", "??", "*र", "???"},
+			want: &(map[string][]string{
+				"न?स्?ार": {"नमस्कार"},
+				"न*":      {"नमस्कार", "नमस्ते"},
+				"??":      {"मोक्ष"},
+				"*र":      {"नमस्कार", "सुंदर"},
+				"???":     {"नमस्ते", "सुंदर"},
+			}),
+		},
+		{
+			name:     "Given a Lexicon with some words, when SearchPattern is invoked for nil patterns array, then error is expected",
+			patterns: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsMatching(tt.patterns...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsMatching() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsMatching() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsMatchingRegexp(t *testing.T) {
+	tests := []struct {
+		name        string
+		expressions []string
+		want        *map[string][]string
+		wantErr     bool
+	}{
+		{
+			name:        "Given a Lexicon with some words, when SearchRegexp is invoked for mix of matching & non matching expressions, then return all the words matching the expressions sorted lexicographically",
+			expressions: []string{"^न", "्[कष]", "र$", "somethingelse"},
+			want: &(map[string][]string{
+				"^न":    {"नमस्कार", "नमस्ते"},
+				"्[कष]": {"नमस्कार", "मोक्ष"},
+				"र$":    {"नमस्कार", "सुंदर"},
+			}),
+		},
+		{
+			name:        "Given a Lexicon with some words, when SearchRegexp is invoked for an invalid expression, then error is expected",
+			expressions: []string{"^न", "[क"},
+			wantErr:     true,
+		},
+		{
+			name:        "Given a Lexicon with some words, when SearchRegexp is invoked for nil expressions array, then error is expected",
+			expressions: nil,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsMatchingRegexp(tt.expressions...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsMatchingRegexp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsMatchingRegexp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsMatchingRegexpTooMany(t *testing.T) {
+	lxc := Open("")
+	words := make([]string, 0, types.MaxRegexpMatches+1)
+	for i := 0; i <= types.MaxRegexpMatches; i++ {
+		words = append(words, "क"+strings.Repeat("ा", i%90)+string(rune('क'+i/90)))
+	}
+	lxc.Add(words...)
+
+	got, err := lxc.GetAllWordsMatchingRegexp("^क")
+	if !errors.Is(err, types.ErrTooManyMatches) {
+		t.Fatalf("LexiconMemory.GetAllWordsMatchingRegexp() error = %v, want %v", err, types.ErrTooManyMatches)
+	}
+	if len((*got)["^क"]) != types.MaxRegexpMatches {
+		t.Errorf("LexiconMemory.GetAllWordsMatchingRegexp() returned %d words, want %d", len((*got)["^क"]), types.MaxRegexpMatches)
+	}
+}
+
+func TestLexiconMemory_GetAllWordsSimilarTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxDistance int
+		words       []string
+		want        *map[string][]string
+		wantErr     bool
+	}{
+		{
+			name:        "Given a Lexicon with some words, when SearchSimilar is invoked for misspelled words, then return the similar words closest first",
+			maxDistance: 1,
+			words:       []string{"नमस्कीर", "नमस्त", "मोक्ष", "somethingelse"},
+			want: &(map[string][]string{
+				"नमस्कीर": {"नमस्कार"},
+				"नमस्त":   {"नमस्ते"},
+				"मोक्ष":   {"मोक्ष"},
+			}),
+		},
+		{
+			name:        "Given a Lexicon with some words, when SearchSimilar is invoked with larger distance, then farther words are returned after the closer ones",
+			maxDistance: 2,
+			words:       []string{"नमस्त"},
+			want: &(map[string][]string{
+				"नमस्त": {"नमस्ते", "नमस्कार"},
+			}),
+		},
+		{
+			name:        "Given a Lexicon with some words, when SearchSimilar is invoked with negative distance, then error is expected",
+			maxDistance: -1,
+			words:       []string{"नमस्त"},
+			wantErr:     true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchSimilar is invoked for nil words array, then error is expected",
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsSimilarTo(tt.maxDistance, tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsSimilarTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsSimilarTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsSoundingLike(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		want    *map[string][]string
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when SearchSoundingLike is invoked for words spelled differently, then return the words sounding alike",
+			words: []string{"नमसते", "सुन्दर", "दन्यवाद", "मोकश", "somethingelse"},
+			want: &(map[string][]string{
+				"नमसते":   {"नमस्ते"},
+				"सुन्दर":  {"सुंदर"},
+				"दन्यवाद": {"धन्यवाद"},
+				"मोकश":    {"मोक्ष"},
+			}),
+		},
+		{
+			name:  "Given a Lexicon with some words, when SearchSoundingLike is invoked for an existing word, then return the word itself",
+			words: []string{"नमस्कार"},
+			want: &(map[string][]string{
+				"नमस्कार": {"नमस्कार"},
+			}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchSoundingLike is invoked for nil words array, then error is expected",
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsSoundingLike(tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsSoundingLike() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsSoundingLike() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsRhymingWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		syllables  int
+		strictness rhyme.Strictness
+		words      []string
+		want       *map[string][]string
+		wantErr    bool
+	}{
+		{
+			name:       "Given a Lexicon with some words, when SearchRhymes is invoked with final strictness, then return the words with same last akshara and vowels",
+			syllables:  2,
+			strictness: rhyme.Final,
+			words:      []string{"संस्कार", "बंदर", "नमस्ते", "somethingelse"},
+			want: &(map[string][]string{
+				"संस्कार": {"नमस्कार"},
+				"बंदर":    {"सुंदर"},
+			}),
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchRhymes is invoked with vowel strictness, then return the words with same vowels",
+			syllables:  1,
+			strictness: rhyme.Vowel,
+			words:      []string{"घर"},
+			want: &(map[string][]string{
+				"घर": {"धन्यवाद", "नमस्कार", "मोक्ष", "सुंदर"},
+			}),
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchRhymes is invoked with exact strictness, then return the words with same aksharas",
+			syllables:  2,
+			strictness: rhyme.Exact,
+			words:      []string{"संस्कार", "पुकार"},
+			want: &(map[string][]string{
+				"संस्कार": {"नमस्कार"},
+			}),
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchRhymes is invoked with no syllables, then error is expected",
+			syllables:  0,
+			strictness: rhyme.Final,
+			words:      []string{"संस्कार"},
+			wantErr:    true,
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchRhymes is invoked with unknown strictness, then error is expected",
+			syllables:  2,
+			strictness: rhyme.Strictness("loose"),
+			words:      []string{"संस्कार"},
+			wantErr:    true,
+		},
+		{
+			name:       "Given a Lexicon with some words, when SearchRhymes is invoked for nil words array, then error is expected",
+			syllables:  2,
+			strictness: rhyme.Final,
+			words:      nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsRhymingWith(tt.syllables, tt.strictness, tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsRhymingWith() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsRhymingWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllAnagramsOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		want    *map[string][]string
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when SearchAnagrams is invoked for reordered aksharas, then return the words made of same aksharas",
+			words: []string{"मनस्ते", "रस्कानम", "नमस्ते", "मनस्त", "somethingelse"},
+			want: &(map[string][]string{
+				"मनस्ते":  {"नमस्ते"},
+				"रस्कानम": {"नमस्कार"},
+				"नमस्ते":  {"नमस्ते"},
+			}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchAnagrams is invoked for nil words array, then error is expected",
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllAnagramsOf(tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllAnagramsOf() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllAnagramsOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_GetAllWordsFromTiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		tiles   []string
+		want    *map[string][]string
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when SearchTiles is invoked, then return the words formed from some of the tiles",
+			tiles: []string{"रस्तेनस्कामद", "मोक्षमो", "नस्ते"},
+			want: &(map[string][]string{
+				"रस्तेनस्कामद": {"नमस्कार", "नमस्ते"},
+				"मोक्षमो":      {"मोक्ष"},
+			}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchTiles is invoked for too many tiles, then error is expected",
+			tiles:   []string{"कखगघङचछजझञटठडढणतथ"},
+			wantErr: true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchTiles is invoked for nil tiles array, then error is expected",
+			tiles:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().GetAllWordsFromTiles(tt.tiles...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.GetAllWordsFromTiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsFromTiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_Suggest(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		words   []string
+		want    *map[string][]string
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when Suggest is invoked for misspelled words, then return the likely corrections of unknown words only",
+			n:     2,
+			words: []string{"सुन्दर", "नमसते", "नमस्ते", "पुस्तक"},
+			want: &(map[string][]string{
+				"सुन्दर": {"सुंदर"},
+				"नमसते":  {"नमस्ते", "नमस्कार"},
+				"पुस्तक": {},
+			}),
+		},
+		{
+			name:  "Given a Lexicon with some words, when Suggest is invoked for one suggestion, then return only the most likely correction",
+			n:     1,
+			words: []string{"नमसते"},
+			want: &(map[string][]string{
+				"नमसते": {"नमस्ते"},
+			}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when Suggest is invoked for no suggestions, then error is expected",
+			n:       0,
+			words:   []string{"नमसते"},
+			wantErr: true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Suggest is invoked for nil words array, then error is expected",
+			n:       1,
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().Suggest(tt.n, tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.Suggest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.Suggest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_SearchOptions(t *testing.T) {
+	containing := func(lxc *LexiconMemory, options types.SearchOptions) (*map[string][]string, error) {
+		return lxc.GetAllWordsContainingContext(context.Background(), options, "्")
+	}
+	startingWith := func(lxc *LexiconMemory, options types.SearchOptions) (*map[string][]string, error) {
+		return lxc.GetAllWordsStartingWithContext(context.Background(), options, "न")
+	}
+	endingWith := func(lxc *LexiconMemory, options types.SearchOptions) (*map[string][]string, error) {
+		return lxc.GetAllWordsEndingWithContext(context.Background(), options, "र")
+	}
+
+	tests := []struct {
+		name    string
+		options types.SearchOptions
+		search  func(lxc *LexiconMemory, options types.SearchOptions) (*map[string][]string, error)
+		want    *map[string][]string
+	}{
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with minimum aksharas, then return the words having at least as many aksharas",
+			options: types.SearchOptions{MinAksharas: 4},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"धन्यवाद", "नमस्कार"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with maximum aksharas, then return the words having at most as many aksharas",
+			options: types.SearchOptions{MaxAksharas: 3},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"नमस्ते", "मोक्ष"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with minimum & maximum length, then return the words having as many characters",
+			options: types.SearchOptions{MinLength: 6, MaxLength: 6},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"नमस्ते"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchEndingWith is invoked with maximum length, then return the words having at most as many characters",
+			options: types.SearchOptions{MaxLength: 5},
+			search:  endingWith,
+			want:    &(map[string][]string{"र": {"सुंदर"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchStartingWith is invoked with excluded words, then return the words which are not excluded",
+			options: types.SearchOptions{Exclude: []string{"नमस्कार"}},
+			search:  startingWith,
+			want:    &(map[string][]string{"न": {"नमस्ते"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with limit, then return the first page of the words",
+			options: types.SearchOptions{Limit: 2},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"धन्यवाद", "नमस्कार"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with limit & offset, then return the words after the offset",
+			options: types.SearchOptions{Limit: 2, Offset: 1},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"नमस्कार", "नमस्ते"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with limit & cursor, then return the words after the cursor",
+			options: types.SearchOptions{Limit: 2, After: "नमस्कार"},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"नमस्ते", "मोक्ष"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with a cursor which is not a word, then return the words greater than the cursor",
+			options: types.SearchOptions{After: "नमस"},
+			search:  containing,
+			want:    &(map[string][]string{"्": {"नमस्कार", "नमस्ते", "मोक्ष"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked with the cursor of the last word, then return no response for the substring",
+			options: types.SearchOptions{After: "मोक्ष"},
+			search:  containing,
+			want:    &map[string][]string{},
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchRegexp is invoked with limit & cursor, then return the matching words after the cursor",
+			options: types.SearchOptions{Limit: 1, After: "धन्यवाद"},
+			search: func(lxc *LexiconMemory, options types.SearchOptions) (*map[string][]string, error) {
+				return lxc.GetAllWordsMatchingRegexpContext(context.Background(), options, "न")
+			},
+			want: &(map[string][]string{"न": {"नमस्कार"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchStartingWith is invoked with options no word satisfies, then return no response for the substring",
+			options: types.SearchOptions{MinAksharas: 5},
+			search:  startingWith,
+			want:    &map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.search(getLexicon(), tt.options)
+			if err != nil {
+				t.Errorf("LexiconMemory search error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory search = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_Order(t *testing.T) {
+	lxc := Open("")
+	lxc.Add("साप", "संत", "सौर", "सत")
+
+	tests := []struct {
+		name    string
+		options types.SearchOptions
+		want    *map[string][]string
+	}{
+		{
+			name:    "Given a Lexicon with some words, when SearchStartingWith is invoked without an order, then return the words in dictionary order",
+			options: types.SearchOptions{},
+			want:    &(map[string][]string{"स": {"सत", "साप", "सौर", "संत"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchStartingWith is invoked with codepoint order, then return the words in order of their code points",
+			options: types.SearchOptions{Order: types.OrderCodepoint},
+			want:    &(map[string][]string{"स": {"संत", "सत", "साप", "सौर"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchStartingWith is invoked with dictionary order, limit & cursor, then return the words after the cursor in dictionary order",
+			options: types.SearchOptions{Order: types.OrderDictionary, Limit: 2, After: "साप"},
+			want:    &(map[string][]string{"स": {"सौर", "संत"}}),
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchStartingWith is invoked with dictionary order & a cursor which is not a word, then return the words following the cursor in dictionary order",
+			options: types.SearchOptions{After: "सा"},
+			want:    &(map[string][]string{"स": {"साप", "सौर", "संत"}}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lxc.GetAllWordsStartingWithContext(context.Background(), tt.options, "स")
+			if err != nil {
+				t.Errorf("LexiconMemory.GetAllWordsStartingWithContext() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.GetAllWordsStartingWithContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_PaginationRanked(t *testing.T) {
+	lxc := Open("")
+	lxc.Add("कमल", "कमर", "कमला", "नमक")
+
+	options := types.SearchOptions{Limit: 2, After: "कमर"}
+	want := &(map[string][]string{"कमल": {"कमला", "नमक"}})
+	if got, err := lxc.GetAllWordsSimilarToContext(context.Background(), options, 2, "कमल"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("LexiconMemory.GetAllWordsSimilarToContext() = %v, %v, want %v", got, err, want)
+	}
+
+	// a cursor which is not one of the ranked words has no place among them
+	lxc.Remove("कमर")
+	if _, err := lxc.GetAllWordsSimilarToContext(context.Background(), options, 2, "कमल"); !errors.Is(err, types.ErrCursorNotFound) {
+		t.Errorf("LexiconMemory.GetAllWordsSimilarToContext() error = %v, want %v", err, types.ErrCursorNotFound)
+	}
+}
+
+func TestLexiconMemory_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		words   []string
+		want    *types.AddResult
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when Add is invoked for mix of new, existing, repeated & blank words, then every word is reported under correct outcome",
+			words: []string{"देव", "नमस्कार", "देव", " "},
+			want: &types.AddResult{
+				Inserted: []string{"देव"},
+				Existing: []string{"देव", "नमस्कार"},
+				Rejected: []types.RejectedWord{{Word: " ", Reason: "word is empty"}},
+			},
+		},
+		{
+			name:    "Given a Lexicon with some words, when Add is invoked for nil words array, then error is expected",
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLexicon().Add(tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LexiconMemory.Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexiconMemory_AddStream(t *testing.T) {
+	chunks := [][]string{{"देव", "नमस्कार"}, {"पानी", "देव"}}
+	next := func() ([]string, error) {
+		if len(chunks) == 0 {
+			return nil, nil
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}
+
+	progress := make([]int, 0)
+	got := make([]*types.AddResult, 0)
+	err := getLexicon().AddStreamContext(context.Background(), next, func(result *types.AddResult) {
+		got = append(got, result)
+	}, func(done, total int) {
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatalf("LexiconMemory.AddStreamContext() error = %v", err)
+	}
+
+	want := []*types.AddResult{
+		{Inserted: []string{"देव"}, Existing: []string{"नमस्कार"}, Rejected: []types.RejectedWord{}},
+		{Inserted: []string{"पानी"}, Existing: []string{"देव"}, Rejected: []types.RejectedWord{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LexiconMemory.AddStreamContext() = %v, want %v", got, want)
+	}
+	if wantProgress := []int{2, 4}; !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("LexiconMemory.AddStreamContext() progress = %v, want %v", progress, wantProgress)
+	}
+
+	failed := errors.New("input failed")
+	err = getLexicon().AddStream(func() ([]string, error) { return nil, failed }, func(*types.AddResult) {}, nil)
+	if !errors.Is(err, failed) {
+		t.Errorf("LexiconMemory.AddStream() error = %v, want %v", err, failed)
+	}
+}
+
+func TestLexiconMemory_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lexicon.txt")
+
+	lxc := Open(path)
+	lxc.Add(randomWordsInsertedOnInit[:]...)
+	lxc.Close()
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("LexiconMemory.Close() did not save the lexicon: %v", err)
+	}
+
+	got, err := Open(path).Lookup(randomWordsInsertedOnInit[:]...)
+	if err != nil {
+		t.Fatalf("LexiconMemory.Lookup() error = %v", err)
+	}
+	if !reflect.DeepEqual(*got, randomWordsInsertedOnInit[:]) {
+		t.Errorf("LexiconMemory.Lookup() after reopen = %v, want %v", *got, randomWordsInsertedOnInit)
+	}
+}
+
+func TestLexiconMemory_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lxc := getLexicon()
+	if _, err := lxc.LookupContext(ctx, "नमस्ते"); !errors.Is(err, context.Canceled) {
+		t.Errorf("LexiconMemory.LookupContext() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := lxc.GetAllWordsStartingWithContext(ctx, types.SearchOptions{}, "न"); !errors.Is(err, context.Canceled) {
+		t.Errorf("LexiconMemory.GetAllWordsStartingWithContext() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := lxc.AddContext(ctx, "देव"); !errors.Is(err, context.Canceled) {
+		t.Errorf("LexiconMemory.AddContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestLexiconMemory_Remove(t *testing.T) {
+	lxc := getLexicon()
+
+	tests := []struct {
+		name    string
+		words   []string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "Given a Lexicon with some words, when Remove is invoked for mix of existing & non existing words, then only existing words are removed and counted",
+			words: []string{"नमस्ते", "notexists", "नमस्"},
+			want:  1,
+		},
+		{
+			name:  "Given a Lexicon with some words, when Remove is invoked on already removed word, then nothing is removed",
+			words: []string{"नमस्ते"},
+			want:  0,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Remove is invoked for nil words array, then error is expected",
+			words:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lxc.Remove(tt.words...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LexiconMemory.Remove() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LexiconMemory.Remove() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// words sharing the prefix or suffix of the removed word must remain searchable
+	want := &(map[string][]string{"नमस्": {"नमस्कार"}, "ते": nil})
+	starts, _ := lxc.GetAllWordsStartingWith("नमस्")
+	ends, _ := lxc.GetAllWordsEndingWith("ते")
+	got := &(map[string][]string{"नमस्": (*starts)["नमस्"], "ते": (*ends)["ते"]})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LexiconMemory after Remove() = %v, want %v", got, want)
+	}
+
+	if contains, _ := lxc.GetAllWordsContaining("मस्"); !reflect.DeepEqual((*contains)["मस्"], []string{"नमस्कार"}) {
+		t.Errorf("LexiconMemory after Remove() = %v, want %v", *contains, []string{"नमस्कार"})
+	}
+}
+
+func TestLexiconMemory_Rewrite(t *testing.T) {
+	// precomposed क़ (U+0958) and क + nukta are two encodings of the same letter
+	precomposed, decomposed := "\u0958लम", "\u0915\u093cलम"
+	toDecomposed := func(word string) string {
+		return strings.ReplaceAll(word, "\u0958", "\u0915\u093c")
+	}
+
+	lxc := getLexicon()
+	lxc.Add(precomposed, decomposed, "\u0958ानून")
+
+	got, err := lxc.Rewrite(toDecomposed)
+	if err != nil {
+		t.Fatalf("LexiconMemory.Rewrite() error = %v", err)
+	}
+	if want := 2; got != want {
+		t.Errorf("LexiconMemory.Rewrite() = %v, want %v", got, want)
+	}
+
+	wantWords := &(map[string][]string{"\u0915\u093c": {decomposed, "\u0915\u093cानून"}})
+	gotWords, _ := lxc.GetAllWordsStartingWith("\u0915\u093c")
+	if !reflect.DeepEqual(gotWords, wantWords) {
+		t.Errorf("LexiconMemory after Rewrite() = %v, want %v", gotWords, wantWords)
+	}
+
+	if gotOld, _ := lxc.Lookup(precomposed); len(*gotOld) != 0 {
+		t.Errorf("LexiconMemory after Rewrite() old form %v still exists", *gotOld)
+	}
+
+	if _, err := lxc.Rewrite(nil); err == nil {
+		t.Errorf("LexiconMemory.Rewrite(nil) error = nil, want error")
+	}
+}
@@ -0,0 +1,93 @@
+package lexicon
+
+import (
+	"sort"
+	"strings"
+)
+
+// An ngramIndex maps every single rune and every pair of adjacent runes (bigram) of the words to the words
+// having them, so the words containing a substring are found among the words sharing its rarest n-gram
+// instead of visiting every word.
+type ngramIndex struct {
+	postings map[string]map[string]struct{}
+}
+
+func newNgramIndex() *ngramIndex {
+	return &ngramIndex{postings: make(map[string]map[string]struct{})}
+}
+
+// insert adds the word to the postings of all its n-grams.
+func (idx *ngramIndex) insert(word []rune) {
+	w := string(word)
+	for _, gram := range ngrams(word) {
+		words, ok := idx.postings[gram]
+		if !ok {
+			words = make(map[string]struct{})
+			idx.postings[gram] = words
+		}
+		words[w] = struct{}{}
+	}
+}
+
+// remove deletes the word from the postings of all its n-grams, postings left without any word are dropped.
+func (idx *ngramIndex) remove(word []rune) {
+	w := string(word)
+	for _, gram := range ngrams(word) {
+		if words, ok := idx.postings[gram]; ok {
+			delete(words, w)
+			if len(words) == 0 {
+				delete(idx.postings, gram)
+			}
+		}
+	}
+}
+
+// containing returns all the words containing the non empty substring, ordered by code point.
+func (idx *ngramIndex) containing(substring []rune) []string {
+	// bigrams of the substring are more selective than its runes, a single rune is its own n-gram
+	grams := []string{string(substring)}
+	if len(substring) > 1 {
+		grams = grams[:0]
+		for i := 0; i+1 < len(substring); i++ {
+			grams = append(grams, string(substring[i:i+2]))
+		}
+	}
+
+	var candidates map[string]struct{}
+	for _, gram := range grams {
+		words, ok := idx.postings[gram]
+		if !ok {
+			return nil
+		} else if candidates == nil || len(words) < len(candidates) {
+			candidates = words
+		}
+	}
+
+	s := string(substring)
+	matches := make([]string, 0)
+	for word := range candidates {
+		if strings.Contains(word, s) {
+			matches = append(matches, word)
+		}
+	}
+	sort.Strings(matches)
+
+	return matches
+}
+
+// ngrams returns the distinct runes and bigrams of the word.
+func ngrams(word []rune) []string {
+	seen := make(map[string]struct{}, 2*len(word))
+	grams := make([]string, 0, 2*len(word))
+	for i := range word {
+		for n := 1; n <= 2 && i+n <= len(word); n++ {
+			gram := string(word[i : i+n])
+			if _, ok := seen[gram]; !ok {
+				seen[gram] = struct{}{}
+				grams = append(grams, gram)
+			}
+		}
+	}
+
+	return grams
+}
@@ -0,0 +1,133 @@
+package lexicon
+
+import (
+	"sort"
+)
+
+// A trie is a prefix tree keyed on runes rather than bytes, a single Devanagari letter takes
+// multiple bytes so keying on runes keeps every node a valid (partial) word.
+type trie struct {
+	root *trieNode
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	terminal bool // true if a word ends at this node
+}
+
+func newTrie() *trie {
+	return &trie{root: &trieNode{}}
+}
+
+// insert adds the given word to the trie.
+// It returns false if the word was already present.
+func (t *trie) insert(word []rune) bool {
+	node := t.root
+	for _, r := range word {
+		if node.children == nil {
+			node.children = make(map[rune]*trieNode)
+		}
+
+		child, ok := node.children[r]
+		if !ok {
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+
+	if node.terminal {
+		return false
+	}
+
+	node.terminal = true
+	return true
+}
+
+// remove deletes the given word from the trie, nodes left without any word are pruned.
+// It returns false if the word was not present.
+func (t *trie) remove(word []rune) bool {
+	// remember the path to prune the nodes bottom up
+	path := make([]*trieNode, 0, len(word)+1)
+	node := t.root
+	path = append(path, node)
+	for _, r := range word {
+		if node = node.children[r]; node == nil {
+			return false
+		}
+		path = append(path, node)
+	}
+
+	if !node.terminal {
+		return false
+	}
+
+	node.terminal = false
+	for i := len(word); i > 0 && !path[i].terminal && len(path[i].children) == 0; i-- {
+		delete(path[i-1].children, word[i-1])
+	}
+
+	return true
+}
+
+// contains checks if the exact word is present in the trie.
+func (t *trie) contains(word []rune) bool {
+	node := t.find(word)
+	return node != nil && node.terminal
+}
+
+// find returns the node at which the given prefix ends, nil if no such prefix exists.
+func (t *trie) find(prefix []rune) *trieNode {
+	node := t.root
+	for _, r := range prefix {
+		if node = node.children[r]; node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// withPrefix returns all the words that start with the given prefix, ordered by code point.
+func (t *trie) withPrefix(prefix []rune) [][]rune {
+	node := t.find(prefix)
+	if node == nil {
+		return nil
+	}
+
+	words := make([][]rune, 0)
+	path := append(make([]rune, 0, len(prefix)+16), prefix...)
+	node.walk(path, func(word []rune) {
+		words = append(words, append([]rune(nil), word...))
+	})
+
+	return words
+}
+
+// walk visits every word in the subtree of this node in code point order.
+// `path` holds the runes leading to this node, `visit` must not retain it.
+func (n *trieNode) walk(path []rune, visit func(word []rune)) {
+	if n.terminal {
+		visit(path)
+	}
+
+	keys := make([]rune, 0, len(n.children))
+	for r := range n.children {
+		keys = append(keys, r)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for _, r := range keys {
+		n.children[r].walk(append(path, r), visit)
+	}
+}
+
+// reversed returns a new slice with runes of the word in reverse order.
+func reversed(word []rune) []rune {
+	rev := make([]rune, len(word))
+	for i, r := range word {
+		rev[len(word)-1-i] = r
+	}
+
+	return rev
+}
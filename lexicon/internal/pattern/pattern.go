@@ -0,0 +1,150 @@
+// Package pattern matches words against wildcard patterns made of aksharas.
+//
+// In a pattern `?` matches exactly one akshara and `*` matches any run of aksharas, including none, every
+// other character is matched literally. A literal may cover a part of the akshara matched by an adjacent
+// wildcard, a literal ending with virama continues into the akshara of the next wildcard and a literal
+// starting with a vowel sign or other combining mark completes the akshara of the previous wildcard,
+// e.g. "न?स्?ार" matches "नमस्कार" where the second `?` is the क of स्का.
+package pattern
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+const (
+	One  = '?' // wildcard matching exactly one akshara
+	Many = '*' // wildcard matching any run of aksharas
+
+	// boundary marks the end of every akshara of a word while matching, it never occurs in a word.
+	boundary = "\x1f"
+)
+
+// A Pattern is a compiled wildcard pattern, it is safe for concurrent use.
+type Pattern struct {
+	re     *regexp.Regexp
+	prefix string // literal text before the first wildcard
+}
+
+// Compile returns the Pattern of the given text, every text is a valid pattern.
+func Compile(text string) *Pattern {
+	tokens := tokenize(text)
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i, token := range tokens {
+		var nextToken string
+		if i+1 < len(tokens) {
+			nextToken = tokens[i+1]
+		}
+		continuesAkshara := startsWithMark(nextToken)
+
+		switch token {
+		case string(One):
+			sb.WriteString("[^" + boundary + "]+")
+			if !continuesAkshara {
+				sb.WriteString(boundary)
+			}
+		case string(Many):
+			if continuesAkshara {
+				sb.WriteString(".*")
+			} else {
+				sb.WriteString("(?:.*" + boundary + ")?")
+			}
+		default:
+			// literal runes may be at the end of an akshara or within it, the last one must end the akshara
+			// unless the literal or the following wildcard continues it
+			runes := []rune(token)
+			for j, r := range runes {
+				sb.WriteString(regexp.QuoteMeta(string(r)))
+				if j+1 < len(runes) {
+					sb.WriteString(boundary + "?")
+				} else if r != akshara.Virama || len(nextToken) == 0 {
+					sb.WriteString(boundary)
+				}
+			}
+		}
+	}
+	sb.WriteString("$")
+
+	p := &Pattern{re: regexp.MustCompile(sb.String())}
+	if len(tokens) != 0 && !isWildcard(tokens[0]) {
+		p.prefix = tokens[0]
+	}
+
+	return p
+}
+
+// Match checks if the whole word matches the pattern.
+func (p *Pattern) Match(word string) bool {
+	var sb strings.Builder
+	for _, a := range akshara.Split(word) {
+		sb.WriteString(a)
+		sb.WriteString(boundary)
+	}
+
+	return p.re.MatchString(sb.String())
+}
+
+// Prefix returns the literal text all the matching words start with, empty if the pattern starts with a wildcard.
+func (p *Pattern) Prefix() string {
+	return p.prefix
+}
+
+// Like returns the LIKE pattern which matches every word matching this pattern, and possibly more, as every
+// akshara has at least one character. Literal text is escaped by `escape` before it is added.
+func Like(text string, escape func(value string) string) string {
+	var sb strings.Builder
+	for _, token := range tokenize(text) {
+		switch token {
+		case string(One):
+			sb.WriteString("_%")
+		case string(Many):
+			sb.WriteString("%")
+		default:
+			sb.WriteString(escape(token))
+		}
+	}
+
+	return sb.String()
+}
+
+// tokenize splits the pattern into the wildcards and the runs of literal text between them.
+func tokenize(text string) []string {
+	tokens := make([]string, 0)
+	start := 0
+	for i, r := range text {
+		if r == One || r == Many {
+			if i > start {
+				tokens = append(tokens, text[start:i])
+			}
+			tokens = append(tokens, string(r))
+			start = i + 1
+		}
+	}
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+
+	return tokens
+}
+
+func isWildcard(token string) bool {
+	return token == string(One) || token == string(Many)
+}
+
+// startsWithMark checks if the literal token starts with a combining mark, i.e. it completes the previous akshara.
+func startsWithMark(token string) bool {
+	if isWildcard(token) || len(token) == 0 {
+		return false
+	}
+
+	for _, r := range token {
+		return unicode.Is(unicode.M, r)
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestPattern_Match(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		word    string
+		want    bool
+	}{
+		{
+			name:    "Given a pattern with `?` within a conjunct, when Match is invoked, then the word matches",
+			pattern: "न?स्?ार",
+			word:    "नमस्कार",
+			want:    true,
+		},
+		{
+			name:    "Given a pattern with `*`, when Match is invoked, then any run of aksharas matches",
+			pattern: "न*र",
+			word:    "नमस्कार",
+			want:    true,
+		},
+		{
+			name:    "Given a pattern of one `?`, when Match is invoked on a conjunct, then the conjunct is one akshara",
+			pattern: "?",
+			word:    "क्ष",
+			want:    true,
+		},
+		{
+			name:    "Given a pattern of two `?`, when Match is invoked on a conjunct, then the word does not match",
+			pattern: "??",
+			word:    "क्ष",
+			want:    false,
+		},
+		{
+			name:    "Given a pattern with literal consonant and `*`, when Match is invoked on a conjunct starting with it, then the word does not match",
+			pattern: "क*",
+			word:    "क्ष",
+			want:    false,
+		},
+		{
+			name:    "Given a pattern with `?` followed by a vowel sign, when Match is invoked, then the vowel sign completes the akshara",
+			pattern: "?ा",
+			word:    "क्षा",
+			want:    true,
+		},
+		{
+			name:    "Given a pattern without wildcards, when Match is invoked on a longer word, then the whole word must match",
+			pattern: "नम",
+			word:    "नमस्कार",
+			want:    false,
+		},
+		{
+			name:    "Given a pattern of `*` alone, when Match is invoked, then every word matches",
+			pattern: "*",
+			word:    "मोक्ष",
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compile(tt.pattern).Match(tt.word); got != tt.want {
+				t.Errorf("Compile(%q).Match(%q) = %v, want %v", tt.pattern, tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLike(t *testing.T) {
+	escape := func(value string) string { return value }
+	if got, want := Like("न?स्*र", escape), "न_%स्%र"; got != want {
+		t.Errorf("Like() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+// Package phonetic encodes Devanagari words by how they sound, a Soundex analogue for the script.
+//
+// Words which are pronounced alike but spelled differently have the same key, e.g. नमस्ते & नमसते or
+// भारत & बारत. The key is built by
+//   - writing the aspirated consonants as their unaspirated pair, ख as क, भ as ब and so on
+//   - writing the sibilants श & ष as स
+//   - writing the long vowels ई, ऊ & ॠ and their vowel signs as the short ones, अ & आ are kept apart
+//   - writing candrabindu and a half nasal consonant before another consonant as anusvara
+//   - dropping nukta, virama and the zero width joiners, so a conjunct sounds like its consonants
+//   - writing ऋ as रि and a geminate, a consonant doubled by virama, as a single consonant
+//
+// Keys are Devanagari text themselves, they are only meant to be compared with each other.
+package phonetic
+
+import (
+	"strings"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+var (
+	// sounds replaces the letters and signs which sound alike by a single one of them.
+	sounds = strings.NewReplacer(
+		"\u0916", "\u0915", "\u0918", "\u0917", // ख & घ
+		"\u091B", "\u091A", "\u091D", "\u091C", // छ & झ
+		"\u0920", "\u091F", "\u0922", "\u0921", // ठ & ढ
+		"\u0925", "\u0924", "\u0927", "\u0926", // थ & ध
+		"\u092B", "\u092A", "\u092D", "\u092C", // फ & भ
+		"\u0936", "\u0938", "\u0937", "\u0938", // sibilants श & ष
+		"\u0933", "\u0932", // ळ
+		"\u0908", "\u0907", "\u0940", "\u093F", // long i, ई & ी
+		"\u090A", "\u0909", "\u0942", "\u0941", // long u, ऊ & ू
+		"\u0960", "\u0930\u093F", "\u090B", "\u0930\u093F", // vocalic r, ॠ & ऋ
+		"\u0944", "\u094D\u0930\u093F", "\u0943", "\u094D\u0930\u093F", // vocalic r signs, ॄ & ृ
+		"\u0901", "\u0902", // candrabindu
+		"\u093C", "", "\u200C", "", "\u200D", "", // nukta & zero width joiners
+		"\u0958", "\u0915", "\u0959", "\u0915", "\u095A", "\u0917", "\u095B", "\u091C", // precomposed nukta letters क़ ख़ ग़ ज़
+		"\u095C", "\u0921", "\u095D", "\u0921", "\u095E", "\u092A", "\u095F", "\u092F", // precomposed nukta letters ड़ ढ़ फ़ य़
+	)
+)
+
+// Key returns the phonetic key of the word, words which sound alike have the same key.
+// A key has at most twice as many characters as the word, as ऋ, ॠ and their vowel signs are written as रि.
+func Key(word string) string {
+	runes := []rune(akshara.FoldNasals(sounds.Replace(word)))
+
+	key := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == akshara.Virama {
+			continue
+		} else if i > 0 && runes[i-1] == akshara.Virama && len(key) != 0 && key[len(key)-1] == r {
+			continue // second half of a geminate
+		}
+
+		key = append(key, r)
+	}
+
+	return string(key)
+}
@@ -0,0 +1,80 @@
+package phonetic
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{
+			name: "Given words differing in aspiration, when Key is invoked, then keys are equal",
+			a:    "भारत",
+			b:    "बारत",
+			want: true,
+		},
+		{
+			name: "Given words differing in sibilants and vowel length, when Key is invoked, then keys are equal",
+			a:    "शिक्षा",
+			b:    "सीकसा",
+			want: true,
+		},
+		{
+			name: "Given words differing in half nasal and candrabindu, when Key is invoked, then keys are equal",
+			a:    "हिन्दी",
+			b:    "हिँदी",
+			want: true,
+		},
+		{
+			name: "Given words differing in virama, when Key is invoked, then keys are equal",
+			a:    "नमस्ते",
+			b:    "नमसते",
+			want: true,
+		},
+		{
+			name: "Given words differing in nukta and geminate, when Key is invoked, then keys are equal",
+			a:    "पक्का",
+			b:    "पक़ा",
+			want: true,
+		},
+		{
+			name: "Given words differing in vocalic r, when Key is invoked, then keys are equal",
+			a:    "कृपा",
+			b:    "क्रिपा",
+			want: true,
+		},
+		{
+			name: "Given words with a repeated consonant and a single consonant, when Key is invoked, then keys differ",
+			a:    "ममता",
+			b:    "मता",
+			want: false,
+		},
+		{
+			name: "Given words differing in length of a, when Key is invoked, then keys differ",
+			a:    "कर",
+			b:    "कार",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Key(tt.a) == Key(tt.b); got != tt.want {
+				t.Errorf("Key(%q) = %q, Key(%q) = %q, want equal %v", tt.a, Key(tt.a), tt.b, Key(tt.b), tt.want)
+			}
+		})
+	}
+}
+
+func TestKey_Length(t *testing.T) {
+	// every ृ of the word is written as रि
+	word := strings.Repeat("कृ", 50)
+	if got, want := utf8.RuneCountInString(Key(word)), 150; got != want {
+		t.Errorf("Key() of %d characters has %d characters, want %d", utf8.RuneCountInString(word), got, want)
+	}
+}
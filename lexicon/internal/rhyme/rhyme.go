@@ -0,0 +1,152 @@
+// Package rhyme finds the words which rhyme with a word by comparing their trailing aksharas.
+//
+// Trailing aksharas, i.e. the final syllables, are compared by their vowel and optionally by their consonants
+// as per the Strictness. The vowel of an akshara is its vowel sign along with anusvara, candrabindu or visarga,
+// an independent vowel is the same as its vowel sign and a consonant without vowel sign has the inherent vowel,
+// e.g. the vowel of का & आ is ा and of र & र् is the inherent vowel.
+//
+// Words can be stored along with their Key, the words which rhyme with a word are then the words whose key starts
+// within the KeyRange of its Rhyme, matched further by Match.
+package rhyme
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+// Strictness tells which parts of the trailing aksharas must be the same for words to rhyme.
+type Strictness string
+
+const (
+	Vowel Strictness = "vowel" // trailing aksharas have the same vowels, e.g. कार & पाल
+	Final Strictness = "final" // last akshara is the same and the other trailing aksharas have the same vowels, e.g. कार & भार
+	Exact Strictness = "exact" // trailing aksharas are the same, e.g. सुधार & उधार
+)
+
+const (
+	// keySeparator ends the vowel of every akshara of a key, keyEnd is the rune right after it
+	keySeparator = ','
+	keyEnd       = '-'
+)
+
+var (
+	errNoSyllables       = errors.New("count of syllables is less than 1")
+	errUnknownStrictness = errors.New("unknown rhyme strictness")
+
+	// independentVowelSigns maps the independent vowels to their vowel signs, अ has the inherent vowel.
+	independentVowelSigns = map[rune]string{
+		'अ': "", 'आ': "ा", 'इ': "ि", 'ई': "ी", 'उ': "ु", 'ऊ': "ू",
+		'ऋ': "ृ", 'ॠ': "ॄ", 'ऌ': "ॢ", 'ॡ': "ॣ",
+		'ऍ': "ॅ", 'ऎ': "ॆ", 'ए': "े", 'ऐ': "ै",
+		'ऑ': "ॉ", 'ऒ': "ॊ", 'ओ': "ो", 'औ': "ौ",
+	}
+)
+
+// A Rhyme matches the words which rhyme with a word, it is safe for concurrent use.
+type Rhyme struct {
+	word       string
+	trailing   []string // trailing aksharas of the word which are compared
+	strictness Strictness
+}
+
+// New returns the Rhyme of the word comparing its last `syllables` aksharas, or all of them if the word is shorter.
+// If `syllables` is less than 1 or the strictness is unknown then error is returned.
+func New(word string, syllables int, strictness Strictness) (*Rhyme, error) {
+	if syllables < 1 {
+		return nil, errNoSyllables
+	} else if strictness != Vowel && strictness != Final && strictness != Exact {
+		return nil, errUnknownStrictness
+	}
+
+	aksharas := akshara.Split(word)
+	return &Rhyme{
+		word:       word,
+		trailing:   aksharas[max(0, len(aksharas)-syllables):],
+		strictness: strictness,
+	}, nil
+}
+
+// Match checks if the word rhymes, a word does not rhyme with itself or with an empty word.
+// Words shorter than the compared aksharas do not rhyme.
+func (r *Rhyme) Match(word string) bool {
+	if word == r.word || len(r.trailing) == 0 {
+		return false
+	}
+
+	aksharas := akshara.Split(word)
+	if len(aksharas) < len(r.trailing) {
+		return false
+	}
+
+	last := len(r.trailing) - 1
+	for i, a := range aksharas[len(aksharas)-len(r.trailing):] {
+		exact := r.strictness == Exact || (r.strictness == Final && i == last)
+		if (exact && a != r.trailing[i]) || (!exact && vowelOf(a) != vowelOf(r.trailing[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Suffix returns the text all the rhyming words end with, empty if they can end with any text.
+func (r *Rhyme) Suffix() string {
+	switch {
+	case len(r.trailing) == 0 || r.strictness == Vowel:
+		return ""
+	case r.strictness == Final:
+		return r.trailing[len(r.trailing)-1]
+	default:
+		return strings.Join(r.trailing, "")
+	}
+}
+
+// Key returns the rhyme key of the word, i.e. the vowels of its aksharas from the last akshara to the first one,
+// every vowel followed by a comma, e.g. the key of नमस्कार is ",ा,,,". Words rhyming with a word, as per any
+// strictness, have the same vowels in their trailing aksharas, so their keys start with the same text.
+func Key(word string) string {
+	aksharas := akshara.Split(word)
+
+	var sb strings.Builder
+	for i := len(aksharas) - 1; i >= 0; i-- {
+		sb.WriteString(vowelOf(aksharas[i]))
+		sb.WriteRune(keySeparator)
+	}
+
+	return sb.String()
+}
+
+// KeyRange returns the range of the keys of all the rhyming words, see Key, keys from `from` inclusive up to `to`
+// exclusive as per the order of their code points. Such keys start with the vowels of the trailing aksharas, the
+// words still have to be matched by Match.
+func (r *Rhyme) KeyRange() (from, to string) {
+	if len(r.trailing) == 0 {
+		return "", ""
+	}
+
+	var sb strings.Builder
+	for i := len(r.trailing) - 1; i >= 0; i-- {
+		sb.WriteString(vowelOf(r.trailing[i]))
+		sb.WriteRune(keySeparator)
+	}
+
+	// the keys starting with the prefix are the only keys sorting between it and the prefix ending with keyEnd
+	from = sb.String()
+	return from, from[:len(from)-1] + string(keyEnd)
+}
+
+// vowelOf returns the vowel of the akshara, see package doc.
+func vowelOf(a string) string {
+	var sb strings.Builder
+	for _, r := range a {
+		if sign, ok := independentVowelSigns[r]; ok {
+			sb.WriteString(sign)
+		} else if !akshara.IsConsonant(r) && r != akshara.Virama && r != akshara.Nukta && r != akshara.ZWNJ && r != akshara.ZWJ {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,174 @@
+package rhyme
+
+import (
+	"testing"
+)
+
+func TestRhyme_Match(t *testing.T) {
+	tests := []struct {
+		name       string
+		word       string
+		syllables  int
+		strictness Strictness
+		candidate  string
+		want       bool
+	}{
+		{
+			name:       "Given words with same last akshara and vowel before it, when Match is invoked with final strictness, then words rhyme",
+			word:       "कार",
+			syllables:  2,
+			strictness: Final,
+			candidate:  "भार",
+			want:       true,
+		},
+		{
+			name:       "Given words with different vowel before the last akshara, when Match is invoked with final strictness, then words do not rhyme",
+			word:       "कार",
+			syllables:  2,
+			strictness: Final,
+			candidate:  "भीर",
+			want:       false,
+		},
+		{
+			name:       "Given words with same vowels only, when Match is invoked with vowel strictness, then words rhyme",
+			word:       "कार",
+			syllables:  2,
+			strictness: Vowel,
+			candidate:  "पाल",
+			want:       true,
+		},
+		{
+			name:       "Given a word ending with an independent vowel, when Match is invoked with vowel strictness, then it rhymes with the vowel sign",
+			word:       "जाओ",
+			syllables:  2,
+			strictness: Vowel,
+			candidate:  "खाको",
+			want:       true,
+		},
+		{
+			name:       "Given words with same vowels only, when Match is invoked with exact strictness, then words do not rhyme",
+			word:       "सुधार",
+			syllables:  2,
+			strictness: Exact,
+			candidate:  "पुकार",
+			want:       false,
+		},
+		{
+			name:       "Given words with same trailing aksharas, when Match is invoked with exact strictness, then words rhyme",
+			word:       "सुधार",
+			syllables:  2,
+			strictness: Exact,
+			candidate:  "उधार",
+			want:       true,
+		},
+		{
+			name:       "Given a word shorter than the compared aksharas, when Match is invoked, then words do not rhyme",
+			word:       "सुधार",
+			syllables:  3,
+			strictness: Vowel,
+			candidate:  "धार",
+			want:       false,
+		},
+		{
+			name:       "Given the word itself, when Match is invoked, then it does not rhyme",
+			word:       "कार",
+			syllables:  2,
+			strictness: Final,
+			candidate:  "कार",
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.word, tt.syllables, tt.strictness)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if got := r.Match(tt.candidate); got != tt.want {
+				t.Errorf("Rhyme.Match(%q) = %v, want %v", tt.candidate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("कार", 0, Final); err == nil {
+		t.Errorf("New() error = nil, want error for no syllables")
+	}
+	if _, err := New("कार", 2, Strictness("loose")); err == nil {
+		t.Errorf("New() error = nil, want error for unknown strictness")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if got, want := Key("नमस्कार"), ",ा,,,"; got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+	if got, want := Key("आइए"), "े,ि,ा,"; got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+}
+
+func TestRhyme_KeyRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		word       string
+		syllables  int
+		strictness Strictness
+		candidate  string
+		want       bool
+	}{
+		{
+			name:       "Given words with same vowels, when KeyRange is invoked, then key of the candidate is within the range",
+			word:       "कार",
+			syllables:  2,
+			strictness: Vowel,
+			candidate:  "नमस्कार",
+			want:       true,
+		},
+		{
+			name:       "Given words with same vowels in different aksharas, when KeyRange is invoked, then key of the candidate is within the range",
+			word:       "आँगन",
+			syllables:  3,
+			strictness: Exact,
+			candidate:  "माँगन",
+			want:       true,
+		},
+		{
+			name:       "Given a candidate with the same last vowel only, when KeyRange is invoked for one syllable, then key of the candidate is within the range",
+			word:       "कमल",
+			syllables:  1,
+			strictness: Vowel,
+			candidate:  "काल",
+			want:       true,
+		},
+		{
+			name:       "Given a candidate with another last vowel, when KeyRange is invoked, then key of the candidate is out of the range",
+			word:       "कमल",
+			syllables:  1,
+			strictness: Vowel,
+			candidate:  "कमली",
+			want:       false,
+		},
+		{
+			name:       "Given a candidate shorter than the syllables, when KeyRange is invoked, then key of the candidate is out of the range",
+			word:       "नमस्कार",
+			syllables:  3,
+			strictness: Vowel,
+			candidate:  "कार",
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := New(tt.word, tt.syllables, tt.strictness)
+			from, to := r.KeyRange()
+			key := Key(tt.candidate)
+			if got := key >= from && key < to; got != tt.want {
+				t.Errorf("Rhyme.KeyRange() = [%q, %q), key %q within = %v, want %v", from, to, key, got, tt.want)
+			}
+		})
+	}
+}
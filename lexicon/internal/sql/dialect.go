@@ -0,0 +1,279 @@
+package lexicon
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	// likeEscape is the escape character of LIKE patterns. Backslash is avoided as its meaning inside
+	// a string literal changes with the server (MySQL `NO_BACKSLASH_ESCAPES`, PostgreSQL `standard_conforming_strings`).
+	likeEscape = "!"
+)
+
+var (
+	likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+)
+
+// A Dialect describes the SQL flavour understood by a database server.
+// LexiconSQL builds every query through its dialect, so supporting a new server does not need
+// driver specific branching in the operations.
+type Dialect interface {
+	// Placeholder returns the bind parameter marker for the n-th (starting from 1) argument of a query.
+	Placeholder(n int) string
+
+	// InsertIgnore returns a query which inserts `rows` rows of values for the `columns` of `table`,
+	// rows which already exist are silently skipped. Values are bound row after row.
+	InsertIgnore(table string, columns []string, rows int) string
+
+	// EscapeLike escapes the LIKE wildcards present in the value so that they are matched literally.
+	// Escaped values must only be used with the predicate returned by Like.
+	EscapeLike(value string) string
+
+	// Like returns a LIKE predicate matching `column` against the pattern bound to `placeholder`.
+	Like(column, placeholder string) string
+
+	// Regexp returns a predicate matching `column` against the regular expression bound to `placeholder`,
+	// empty if the server cannot match regular expressions exactly like the regexp package.
+	Regexp(column, placeholder string) string
+
+	// Collate returns an expression of `column` which orders words lexicographically (case insensitive).
+	Collate(column string) string
+
+	// Binary returns an expression of `column` which orders words by their code points, i.e. by their UTF-8 bytes.
+	Binary(column string) string
+
+	// Length returns an expression of the count of characters (code points) of `column`.
+	Length(column string) string
+
+	// After returns a predicate checking if `column` comes after the word bound to `placeholder` when the words
+	// are ordered by Collate and then by the column itself, the predicate of a keyset cursor.
+	After(column, placeholder string) string
+
+	// Limit returns the clause which skips `offset` rows and returns at most `limit` rows, zero `limit` means
+	// all the rows. It is empty if both are zero.
+	Limit(limit, offset int) string
+
+	// MaxBindParameters returns the maximum number of bind parameters a single query can have.
+	MaxBindParameters() int
+}
+
+// dialectOf returns the Dialect for the given database/sql driver name.
+// If the driver is not supported then the function will panic.
+func dialectOf(driver string) Dialect {
+	switch driver {
+	case "mysql":
+		return mysqlDialect{}
+	case "libsql", "sqlite3":
+		return sqliteDialect{}
+	case SQLiteDriver:
+		return sqliteDialect{regexp: true}
+	case "postgres":
+		return postgresDialect{}
+	default:
+		log.Panicf("unsupported driver %s\n", driver)
+		return nil
+	}
+}
+
+// values returns the column list and the VALUES list for `rows` rows of the `columns`,
+// e.g. "(word, phonetic) VALUES (?, ?), (?, ?)" for two columns.
+func values(dialect Dialect, columns []string, rows int) string {
+	var sb strings.Builder
+	sb.WriteString("(" + strings.Join(columns, ", ") + ") VALUES ")
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(" + placeholders(dialect, i*len(columns)+1, len(columns)) + ")")
+	}
+
+	return sb.String()
+}
+
+// placeholders returns comma separated bind parameter markers for `count` arguments starting from
+// the `first` argument, e.g. "?, ?" or "$3, $4".
+func placeholders(dialect Dialect, first, count int) string {
+	var sb strings.Builder
+	for i := first; i < first+count; i++ {
+		if i > first {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(dialect.Placeholder(i))
+	}
+
+	return sb.String()
+}
+
+// like is the LIKE predicate shared by all the dialects, see likeEscape.
+func like(column, placeholder string) string {
+	return fmt.Sprintf("%s LIKE %s ESCAPE '%s'", column, placeholder, likeEscape)
+}
+
+// limitOffset is the LIMIT clause shared by all the dialects, `all` is the count of rows which stands for all the rows
+// as an OFFSET needs a LIMIT.
+func limitOffset(limit, offset int, all string) string {
+	if limit == 0 && offset == 0 {
+		return ""
+	} else if limit > 0 {
+		all = strconv.Itoa(limit)
+	}
+
+	return fmt.Sprintf("LIMIT %s OFFSET %d", all, offset)
+}
+
+// mysqlDialect is the Dialect of MySQL.
+type mysqlDialect struct{}
+
+func (d mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (d mysqlDialect) InsertIgnore(table string, columns []string, rows int) string {
+	return fmt.Sprintf("INSERT IGNORE INTO %s %s", table, values(d, columns, rows))
+}
+
+func (d mysqlDialect) EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+func (d mysqlDialect) Like(column, placeholder string) string {
+	return like(column, placeholder)
+}
+
+func (d mysqlDialect) Regexp(column, placeholder string) string {
+	// REGEXP is ICU whose \w, \d, \s & \b are Unicode aware, it would leave out words which the lexicon matches
+	return ""
+}
+
+func (d mysqlDialect) Collate(column string) string {
+	return column + " COLLATE utf8_unicode_ci"
+}
+
+func (d mysqlDialect) Binary(column string) string {
+	// bytes compare with the arguments whatever the character set of the connection, unlike COLLATE utf8_bin
+	return "CAST(" + column + " AS BINARY)"
+}
+
+func (d mysqlDialect) Length(column string) string {
+	return "CHAR_LENGTH(" + column + ")" // LENGTH counts bytes
+}
+
+func (d mysqlDialect) After(column, placeholder string) string {
+	// the word column already orders like Collate, words which are equal as per it cannot be stored twice
+	return column + " > " + placeholder
+}
+
+func (d mysqlDialect) Limit(n, offset int) string {
+	return limitOffset(n, offset, "18446744073709551615")
+}
+
+func (d mysqlDialect) MaxBindParameters() int {
+	return 65535
+}
+
+// sqliteDialect is the Dialect of SQLite and libSQL.
+type sqliteDialect struct {
+	regexp bool // true if the `regexp` function is registered, see SQLiteDriver
+}
+
+func (d sqliteDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (d sqliteDialect) InsertIgnore(table string, columns []string, rows int) string {
+	return fmt.Sprintf("INSERT OR IGNORE INTO %s %s", table, values(d, columns, rows))
+}
+
+func (d sqliteDialect) EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+func (d sqliteDialect) Like(column, placeholder string) string {
+	return like(column, placeholder)
+}
+
+func (d sqliteDialect) Regexp(column, placeholder string) string {
+	if !d.regexp {
+		return ""
+	}
+
+	return column + " REGEXP " + placeholder
+}
+
+func (d sqliteDialect) Collate(column string) string {
+	return column + " COLLATE NOCASE"
+}
+
+func (d sqliteDialect) Binary(column string) string {
+	return column + " COLLATE BINARY"
+}
+
+func (d sqliteDialect) Length(column string) string {
+	return "LENGTH(" + column + ")"
+}
+
+func (d sqliteDialect) After(column, placeholder string) string {
+	// words which are equal as per the collation of the word column cannot be stored twice
+	return d.Collate(column) + " > " + placeholder
+}
+
+func (d sqliteDialect) Limit(n, offset int) string {
+	return limitOffset(n, offset, "-1")
+}
+
+func (d sqliteDialect) MaxBindParameters() int {
+	return 32766 // SQLITE_MAX_VARIABLE_NUMBER since SQLite 3.32.0
+}
+
+// postgresDialect is the Dialect of PostgreSQL.
+type postgresDialect struct{}
+
+func (d postgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (d postgresDialect) InsertIgnore(table string, columns []string, rows int) string {
+	return fmt.Sprintf("INSERT INTO %s %s ON CONFLICT DO NOTHING", table, values(d, columns, rows))
+}
+
+func (d postgresDialect) EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+func (d postgresDialect) Like(column, placeholder string) string {
+	return like(column, placeholder)
+}
+
+func (d postgresDialect) Regexp(column, placeholder string) string {
+	// `~` understands a different syntax than the expressions accepted by the lexicon
+	return ""
+}
+
+func (d postgresDialect) Collate(column string) string {
+	return "LOWER(" + column + ")"
+}
+
+func (d postgresDialect) Binary(column string) string {
+	return column + ` COLLATE "C"`
+}
+
+func (d postgresDialect) Length(column string) string {
+	return "CHAR_LENGTH(" + column + ")"
+}
+
+func (d postgresDialect) After(column, placeholder string) string {
+	// words differing only in case are stored apart, they are ordered by the column itself
+	return fmt.Sprintf("(%s > %s OR (%s = %s AND %s > %s))",
+		d.Collate(column), d.Collate(placeholder), d.Collate(column), d.Collate(placeholder), column, placeholder)
+}
+
+func (d postgresDialect) Limit(n, offset int) string {
+	return limitOffset(n, offset, "ALL")
+}
+
+func (d postgresDialect) MaxBindParameters() int {
+	return 65535
+}
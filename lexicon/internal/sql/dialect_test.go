@@ -0,0 +1,254 @@
+package lexicon
+
+import (
+	"testing"
+)
+
+func TestDialect_InsertIgnore(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		rows    int
+		want    string
+	}{
+		{
+			name:    "Given MySQL dialect, when InsertIgnore is invoked for multiple rows, then INSERT IGNORE with ? placeholders is expected",
+			dialect: dialectOf("mysql"),
+			rows:    2,
+			want:    "INSERT IGNORE INTO lexicon (word, phonetic, anagram, aksharas, rhyme, sort_key) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)",
+		},
+		{
+			name:    "Given SQLite dialect, when InsertIgnore is invoked for multiple rows, then INSERT OR IGNORE with ? placeholders is expected",
+			dialect: dialectOf("sqlite3"),
+			rows:    2,
+			want:    "INSERT OR IGNORE INTO lexicon (word, phonetic, anagram, aksharas, rhyme, sort_key) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)",
+		},
+		{
+			name:    "Given PostgreSQL dialect, when InsertIgnore is invoked for multiple rows, then ON CONFLICT DO NOTHING with numbered placeholders is expected",
+			dialect: dialectOf("postgres"),
+			rows:    3,
+			want:    "INSERT INTO lexicon (word, phonetic, anagram, aksharas, rhyme, sort_key) VALUES ($1, $2, $3, $4, $5, $6), ($7, $8, $9, $10, $11, $12), ($13, $14, $15, $16, $17, $18) ON CONFLICT DO NOTHING",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.InsertIgnore(tableName, columns, tt.rows); got != tt.want {
+				t.Errorf("Dialect.InsertIgnore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_EscapeLike(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "Given a word without wildcards, when EscapeLike is invoked, then the word is unchanged",
+			value: "नमस्कार",
+			want:  "नमस्कार",
+		},
+		{
+			name:  "Given a word with wildcards and escape character, when EscapeLike is invoked, then all of them are escaped",
+			value: "न%म_स!",
+			want:  "न!%म!_स!!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, driver := range []string{"mysql", "libsql", "postgres"} {
+				if got := dialectOf(driver).EscapeLike(tt.value); got != tt.want {
+					t.Errorf("[%s] Dialect.EscapeLike() = %v, want %v", driver, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDialect_Regexp(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		want   string
+	}{
+		{
+			name:   "Given MySQL dialect, when Regexp is invoked, then no predicate is expected",
+			driver: "mysql",
+			want:   "",
+		},
+		{
+			name:   "Given SQLite dialect with the regexp function, when Regexp is invoked, then REGEXP operator is expected",
+			driver: SQLiteDriver,
+			want:   "l.word REGEXP ?",
+		},
+		{
+			name:   "Given SQLite dialect without the regexp function, when Regexp is invoked, then no predicate is expected",
+			driver: "sqlite3",
+			want:   "",
+		},
+		{
+			name:   "Given PostgreSQL dialect, when Regexp is invoked, then no predicate is expected",
+			driver: "postgres",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialect := dialectOf(tt.driver)
+			if got := dialect.Regexp("l.word", dialect.Placeholder(1)); got != tt.want {
+				t.Errorf("Dialect.Regexp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_Length(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		want   string
+	}{
+		{
+			name:   "Given MySQL dialect, when Length is invoked, then count of characters instead of bytes is expected",
+			driver: "mysql",
+			want:   "CHAR_LENGTH(l.word)",
+		},
+		{
+			name:   "Given SQLite dialect, when Length is invoked, then count of characters is expected",
+			driver: "sqlite3",
+			want:   "LENGTH(l.word)",
+		},
+		{
+			name:   "Given PostgreSQL dialect, when Length is invoked, then count of characters is expected",
+			driver: "postgres",
+			want:   "CHAR_LENGTH(l.word)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dialectOf(tt.driver).Length("l.word"); got != tt.want {
+				t.Errorf("Dialect.Length() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_Binary(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		want   string
+	}{
+		{
+			name:   "Given MySQL dialect, when Binary is invoked, then the bytes of the column are expected",
+			driver: "mysql",
+			want:   "CAST(l.word AS BINARY)",
+		},
+		{
+			name:   "Given SQLite dialect, when Binary is invoked, then binary collation is expected",
+			driver: "sqlite3",
+			want:   "l.word COLLATE BINARY",
+		},
+		{
+			name:   "Given PostgreSQL dialect, when Binary is invoked, then C collation is expected",
+			driver: "postgres",
+			want:   "l.word COLLATE \"C\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dialectOf(tt.driver).Binary("l.word"); got != tt.want {
+				t.Errorf("Dialect.Binary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_After(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		want   string
+	}{
+		{
+			name:   "Given MySQL dialect, when After is invoked, then comparison as per the collation of the column is expected",
+			driver: "mysql",
+			want:   "l.word > ?",
+		},
+		{
+			name:   "Given SQLite dialect, when After is invoked, then case insensitive comparison is expected",
+			driver: "sqlite3",
+			want:   "l.word COLLATE NOCASE > ?",
+		},
+		{
+			name:   "Given PostgreSQL dialect, when After is invoked, then case insensitive comparison ordering same words by their characters is expected",
+			driver: "postgres",
+			want:   "(LOWER(l.word) > LOWER($2) OR (LOWER(l.word) = LOWER($2) AND l.word > $2))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialect := dialectOf(tt.driver)
+			if got := dialect.After("l.word", dialect.Placeholder(2)); got != tt.want {
+				t.Errorf("Dialect.After() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_Limit(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		limit  int
+		offset int
+		want   string
+	}{
+		{
+			name:   "Given MySQL dialect, when Limit is invoked with limit & offset, then LIMIT & OFFSET are expected",
+			driver: "mysql",
+			limit:  10,
+			offset: 20,
+			want:   "LIMIT 10 OFFSET 20",
+		},
+		{
+			name:   "Given MySQL dialect, when Limit is invoked with only offset, then LIMIT of all the rows is expected",
+			driver: "mysql",
+			offset: 20,
+			want:   "LIMIT 18446744073709551615 OFFSET 20",
+		},
+		{
+			name:   "Given SQLite dialect, when Limit is invoked with only offset, then negative LIMIT is expected",
+			driver: "sqlite3",
+			offset: 20,
+			want:   "LIMIT -1 OFFSET 20",
+		},
+		{
+			name:   "Given PostgreSQL dialect, when Limit is invoked with only offset, then LIMIT ALL is expected",
+			driver: "postgres",
+			offset: 20,
+			want:   "LIMIT ALL OFFSET 20",
+		},
+		{
+			name:   "Given PostgreSQL dialect, when Limit is invoked with neither limit nor offset, then no clause is expected",
+			driver: "postgres",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dialectOf(tt.driver).Limit(tt.limit, tt.offset); got != tt.want {
+				t.Errorf("Dialect.Limit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,24 @@
 package lexicon
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/anagram"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/collation"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/fuzzy"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/pattern"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/phonetic"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/rhyme"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/types"
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
 )
 
 const (
@@ -12,37 +26,71 @@ const (
 )
 
 var (
-	errNilOrEmptyWords = errors.New("list of words is nil or empty")
+	// columns are the columns written for every word, the word along with its phonetic & anagram keys,
+	// its count of aksharas, its rhyme key and its collation key
+	columns = []string{"word", "phonetic", "anagram", "aksharas", "rhyme", "sort_key"}
+
+	errNilOrEmptyWords  = errors.New("list of words is nil or empty")
+	errNilRewrite       = errors.New("rewrite function is nil")
+	errNegativeDistance = errors.New("distance is negative")
+	errNoSuggestions    = errors.New("count of suggestions is less than 1")
+	errNoAksharas       = errors.New("count of aksharas is less than 1")
 )
 
 // Open returns an instance of LexiconSQL
-func Open(db *sql.DB, driver string) *LexiconSQL {
+func Open(db *sql.DB, driver string, opts ...Option) *LexiconSQL {
 	if db == nil {
 		log.Panicln("database value is nil")
 	}
 
-	return &LexiconSQL{db, driver}
+	dialect := dialectOf(driver)
+	lxc := &LexiconSQL{
+		db:        db,
+		dialect:   dialect,
+		batchSize: min(defaultBatchSize, dialect.MaxBindParameters()/len(columns)),
+	}
+	for _, opt := range opts {
+		opt(lxc)
+	}
+
+	return lxc
 }
 
 // LexiconSQL provides implementation of Lexicon with SQL DB as backend.
-// Current supported DB are MySQL & libSQL.
+// Current supported DB are MySQL, PostgreSQL, libSQL & SQLite.
 type LexiconSQL struct {
-	db     *sql.DB
-	driver string
+	db        *sql.DB
+	dialect   Dialect
+	batchSize int  // count of words written by a single query
+	atomicAdd bool // true if all the batches of an Add are written in a single transaction
+
+	// similar is the BK-tree of all the words, built on the first similarity search or suggestion and kept up to
+	// date with the changes made through this lexicon. Words changed by other clients of the DB, e.g. another
+	// process, are not seen by it until the lexicon is opened again.
+	mu      sync.Mutex
+	similar *fuzzy.Index
 }
 
 func (lxc *LexiconSQL) Lookup(words ...string) (*[]string, error) {
+	return lxc.LookupContext(context.Background(), words...)
+}
+
+func (lxc *LexiconSQL) LookupContext(ctx context.Context, words ...string) (*[]string, error) {
 	if len(words) == 0 {
 		return nil, errNilOrEmptyWords
 	}
 
-	query := fmt.Sprintf("SELECT EXISTS (SELECT l.word FROM %s l WHERE l.word LIKE ?)", tableName)
+	query := fmt.Sprintf("SELECT EXISTS (SELECT l.word FROM %s l WHERE %s)", tableName, lxc.dialect.Like("l.word", lxc.dialect.Placeholder(1)))
 	exists := make([]string, 0)
 
 	for _, word := range words {
 		exist := false
-		row := lxc.db.QueryRow(query, word)
-		if err := row.Scan(&exist); err == nil && exist {
+		err := lxc.db.QueryRowContext(ctx, query, lxc.dialect.EscapeLike(word)).Scan(&exist)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if exist {
 			exists = append(exists, word)
 		}
 	}
@@ -51,6 +99,10 @@ func (lxc *LexiconSQL) Lookup(words ...string) (*[]string, error) {
 }
 
 func (lxc *LexiconSQL) GetAllWordsStartingWith(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsStartingWithContext(context.Background(), types.SearchOptions{}, substrings...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsStartingWithContext(ctx context.Context, options types.SearchOptions, substrings ...string) (*map[string][]string, error) {
 	if len(substrings) == 0 {
 		return nil, errNilOrEmptyWords
 	}
@@ -58,8 +110,12 @@ func (lxc *LexiconSQL) GetAllWordsStartingWith(substrings ...string) (*map[strin
 	result := make(map[string][]string, 0)
 
 	for _, substring := range substrings {
-		words, err := lxc.searchSubString(substring + "%")
-		if err == nil && len(words) != 0 {
+		words, err := lxc.searchSubString(ctx, options, lxc.dialect.EscapeLike(substring)+"%")
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(words) != 0 {
 			result[substring] = words
 		}
 	}
@@ -68,6 +124,10 @@ func (lxc *LexiconSQL) GetAllWordsStartingWith(substrings ...string) (*map[strin
 }
 
 func (lxc *LexiconSQL) GetAllWordsEndingWith(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsEndingWithContext(context.Background(), types.SearchOptions{}, substrings...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsEndingWithContext(ctx context.Context, options types.SearchOptions, substrings ...string) (*map[string][]string, error) {
 	if len(substrings) == 0 {
 		return nil, errNilOrEmptyWords
 	}
@@ -75,8 +135,12 @@ func (lxc *LexiconSQL) GetAllWordsEndingWith(substrings ...string) (*map[string]
 	result := make(map[string][]string, 0)
 
 	for _, substring := range substrings {
-		words, err := lxc.searchSubString("%" + substring)
-		if err == nil && len(words) != 0 {
+		words, err := lxc.searchSubString(ctx, options, "%"+lxc.dialect.EscapeLike(substring))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(words) != 0 {
 			result[substring] = words
 		}
 	}
@@ -84,60 +148,971 @@ func (lxc *LexiconSQL) GetAllWordsEndingWith(substrings ...string) (*map[string]
 	return &result, nil
 }
 
-func (lxc *LexiconSQL) searchSubString(toSearch string) ([]string, error) {
-	words := make([]string, 0)
-	query := fmt.Sprintf("SELECT l.word FROM %s l WHERE l.word LIKE ?", tableName)
+func (lxc *LexiconSQL) GetAllWordsContaining(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsContainingContext(context.Background(), types.SearchOptions{}, substrings...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsContainingContext(ctx context.Context, options types.SearchOptions, substrings ...string) (*map[string][]string, error) {
+	if len(substrings) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	result := make(map[string][]string, 0)
+
+	for _, substring := range substrings {
+		words, err := lxc.searchSubString(ctx, options, "%"+lxc.dialect.EscapeLike(substring)+"%")
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(words) != 0 {
+			result[substring] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconSQL) GetAllWordsOfAksharaCount(count int, prefixes ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsOfAksharaCountContext(context.Background(), types.SearchOptions{}, count, prefixes...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsOfAksharaCountContext(ctx context.Context, options types.SearchOptions, count int, prefixes ...string) (*map[string][]string, error) {
+	if len(prefixes) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if count < 1 {
+		return nil, errNoAksharas
+	}
+
+	if err := lxc.fillKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	predicate := fmt.Sprintf("l.aksharas = %s AND %s", lxc.dialect.Placeholder(1), lxc.dialect.Like("l.word", lxc.dialect.Placeholder(2)))
+	for _, prefix := range prefixes {
+		words, err := lxc.searchWhere(ctx, options, predicate, count, lxc.dialect.EscapeLike(prefix)+"%")
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(words) != 0 {
+			result[prefix] = words
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconSQL) GetAllWordsMatching(patterns ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsMatchingContext(context.Background(), types.SearchOptions{}, patterns...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsMatchingContext(ctx context.Context, options types.SearchOptions, patterns ...string) (*map[string][]string, error) {
+	if len(patterns) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	result := make(map[string][]string, 0)
+
+	for _, text := range patterns {
+		// LIKE narrows down the words by their characters, aksharas are matched here
+		p := pattern.Compile(text)
+		words, err := lxc.searchMatching(ctx, options, p.Match, 0, lxc.dialect.Like("l.word", lxc.dialect.Placeholder(1)), pattern.Like(text, lxc.dialect.EscapeLike))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(words) != 0 {
+			result[text] = words
+		}
+	}
+
+	return &result, nil
+}
 
-	res, err := lxc.db.Query(query, toSearch)
+func (lxc *LexiconSQL) GetAllWordsMatchingRegexp(expressions ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsMatchingRegexpContext(context.Background(), types.SearchOptions{}, expressions...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsMatchingRegexpContext(ctx context.Context, options types.SearchOptions, expressions ...string) (*map[string][]string, error) {
+	if len(expressions) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	res, err := types.CompileAll(expressions)
 	if err != nil {
-		return []string{}, err
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	var tooMany error
+
+	for i, expression := range expressions {
+		words, err := lxc.searchRegexp(ctx, options, expression, res[i])
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if errors.Is(err, types.ErrTooManyMatches) {
+			tooMany = err
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(words) != 0 {
+			result[expression] = words
+		}
+	}
+
+	return &result, tooMany
+}
+
+// searchRegexp returns the words matching the regular expression, at most types.MaxRegexpMatches of them.
+// The server narrows down the words if it matches regular expressions like the regexp package, i.e. SQLite opened
+// through SQLiteDriver, else every word is read and matched here. Words are always matched by `re` as well.
+// If there are more matching words then they are returned along with types.ErrTooManyMatches.
+func (lxc *LexiconSQL) searchRegexp(ctx context.Context, options types.SearchOptions, expression string, re *regexp.Regexp) ([]string, error) {
+	if predicate := lxc.dialect.Regexp("l.word", lxc.dialect.Placeholder(1)); len(predicate) != 0 {
+		words, err := lxc.searchMatching(ctx, options, re.MatchString, types.MaxRegexpMatches, predicate, expression)
+		if err == nil || errors.Is(err, types.ErrTooManyMatches) || ctx.Err() != nil {
+			return words, err
+		}
+		// the server may not understand the expression, read every word instead
+	}
+
+	return lxc.searchMatching(ctx, options, re.MatchString, types.MaxRegexpMatches, "1 = 1")
+}
+
+// searchMatching returns the page of the words satisfying the predicate on the lexicon `l` which are matched by
+// `match`, `args` are bound to the placeholders of the predicate. The words are streamed in the order of the search
+// options following their cursor, the rest of the page is taken here. Reading stops once the page is full or more
+// than `maxMatches` words match, 0 reads every word.
+func (lxc *LexiconSQL) searchMatching(ctx context.Context, options types.SearchOptions, match func(word string) bool, maxMatches int, predicate string, args ...interface{}) ([]string, error) {
+	predicate, args, err := lxc.filtered(ctx, options, predicate, args)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := lxc.selectWords(ctx, options, predicate, args)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := lxc.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
 	defer res.Close()
 
+	skip := options.Offset
+	words := make([]string, 0)
 	for res.Next() {
 		var word string
 		if err = res.Scan(&word); err != nil {
-			return []string{}, err
+			return nil, err
 		}
 
+		if !match(word) {
+			continue
+		} else if skip > 0 {
+			skip--
+			continue
+		} else if len(words) == options.Limit && options.Limit > 0 {
+			break
+		} else if len(words) == maxMatches && maxMatches > 0 {
+			return words, types.ErrTooManyMatches
+		}
 		words = append(words, word)
 	}
 
+	if err = res.Err(); err != nil {
+		return nil, err
+	}
+
 	return words, nil
 }
 
-func (lxc *LexiconSQL) Add(words ...string) error {
+func (lxc *LexiconSQL) GetAllWordsSimilarTo(maxDistance int, words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsSimilarToContext(context.Background(), types.SearchOptions{}, maxDistance, words...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsSimilarToContext(ctx context.Context, options types.SearchOptions, maxDistance int, words ...string) (*map[string][]string, error) {
 	if len(words) == 0 {
-		return errNilOrEmptyWords
+		return nil, errNilOrEmptyWords
+	} else if maxDistance < 0 {
+		return nil, errNegativeDistance
 	}
 
-	var query string
-	if lxc.driver == "mysql" {
-		query = fmt.Sprintf("INSERT IGNORE INTO %s VALUES ", tableName)
-	} else { // libsql
-		query = fmt.Sprintf("INSERT OR IGNORE INTO %s VALUES ", tableName)
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	if err := lxc.buildSimilar(ctx); err != nil {
+		return nil, err
 	}
 
-	vals := []interface{}{}
-	for _, w := range words {
-		query += "(?), "
-		vals = append(vals, w)
+	// the BK-tree is held here, the options are applied to its words
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if matches := lxc.similar.Search(word, maxDistance); len(matches) != 0 {
+			similar, distances := make([]string, len(matches)), make([]int, len(matches))
+			for i, match := range matches {
+				similar[i], distances[i] = match.Word, match.Distance
+			}
+			similar, err := options.PageRanked(options.Filter(options.SortRanked(similar, distances)))
+			if err != nil {
+				return nil, err
+			} else if len(similar) != 0 {
+				result[word] = similar
+			}
+		}
 	}
-	// trim the last comma (,)
-	query = query[0 : len(query)-2]
 
-	if stmt, err := lxc.db.Prepare(query); err == nil {
+	return &result, nil
+}
+
+func (lxc *LexiconSQL) GetAllWordsSoundingLike(words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsSoundingLikeContext(context.Background(), types.SearchOptions{}, words...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsSoundingLikeContext(ctx context.Context, options types.SearchOptions, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	if err := lxc.fillKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	predicate := "l.phonetic = " + lxc.dialect.Placeholder(1)
+	for _, word := range words {
+		sounding, err := lxc.searchWhere(ctx, options, predicate, phonetic.Key(word))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(sounding) != 0 {
+			result[word] = sounding
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconSQL) GetAllWordsRhymingWith(syllables int, strictness rhyme.Strictness, words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsRhymingWithContext(context.Background(), types.SearchOptions{}, syllables, strictness, words...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsRhymingWithContext(ctx context.Context, options types.SearchOptions, syllables int, strictness rhyme.Strictness, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	if err := lxc.fillKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		r, err := rhyme.New(word, syllables, strictness)
+		if err != nil {
+			return nil, err
+		}
+
+		// the indexed rhyme key narrows down the words to the words with the same trailing vowels, LIKE to the
+		// words with the same ending, the aksharas are compared here
+		from, to := r.KeyRange()
+		predicate, args := fmt.Sprintf("l.rhyme >= %s AND l.rhyme < %s", lxc.dialect.Placeholder(1), lxc.dialect.Placeholder(2)), []interface{}{from, to}
+		if suffix := r.Suffix(); len(suffix) != 0 {
+			predicate += " AND " + lxc.dialect.Like("l.word", lxc.dialect.Placeholder(3))
+			args = append(args, "%"+lxc.dialect.EscapeLike(suffix))
+		}
+
+		rhyming, err := lxc.searchMatching(ctx, options, r.Match, 0, predicate, args...)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(rhyming) != 0 {
+			result[word] = rhyming
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconSQL) GetAllAnagramsOf(words ...string) (*map[string][]string, error) {
+	return lxc.GetAllAnagramsOfContext(context.Background(), types.SearchOptions{}, words...)
+}
+
+func (lxc *LexiconSQL) GetAllAnagramsOfContext(ctx context.Context, options types.SearchOptions, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	if err := lxc.fillKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	predicate := "l.anagram = " + lxc.dialect.Placeholder(1)
+	for _, word := range words {
+		anagrams, err := lxc.searchWhere(ctx, options, predicate, anagram.Key(word))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(anagrams) != 0 {
+			result[word] = anagrams
+		}
+	}
+
+	return &result, nil
+}
+
+func (lxc *LexiconSQL) GetAllWordsFromTiles(tiles ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsFromTilesContext(context.Background(), types.SearchOptions{}, tiles...)
+}
+
+func (lxc *LexiconSQL) GetAllWordsFromTilesContext(ctx context.Context, options types.SearchOptions, tiles ...string) (*map[string][]string, error) {
+	if len(tiles) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if err := types.CheckTiles(tiles); err != nil {
+		return nil, err
+	}
+
+	if err := lxc.fillKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	for _, t := range tiles {
+		words, err := lxc.searchAnagrams(ctx, options, anagram.Keys(t))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, err
+		} else if len(words) != 0 {
+			result[t] = words
+		}
+	}
+
+	return &result, nil
+}
+
+// searchAnagrams returns the page of the words having any of the anagram keys in the order of the search options,
+// keys are looked up in batches.
+func (lxc *LexiconSQL) searchAnagrams(ctx context.Context, options types.SearchOptions, keys []string) ([]string, error) {
+	words := make([]string, 0)
+	for start := 0; start < len(keys); start += lxc.batchSize {
+		batch := keys[start:min(start+lxc.batchSize, len(keys))]
+		predicate, args, err := lxc.filtered(ctx, options, "l.anagram IN ("+placeholders(lxc.dialect, 1, len(batch))+")", asArgs(batch))
+		if err != nil {
+			return []string{}, err
+		}
+
+		res, err := lxc.db.QueryContext(ctx, fmt.Sprintf("SELECT l.word FROM %s l WHERE %s", tableName, predicate), args...)
+		if err != nil {
+			return []string{}, err
+		}
+
+		for res.Next() {
+			var word string
+			if err = res.Scan(&word); err != nil {
+				res.Close()
+				return []string{}, err
+			}
+			words = append(words, word)
+		}
+
+		err = res.Err()
+		res.Close()
+		if err != nil {
+			return []string{}, err
+		}
+	}
+
+	// words of a batch are ordered by the DB, order all of them together, by code points in the storage order
+	if !options.Ordered() {
+		sort.Strings(words)
+	}
+	return options.Page(options.Sort(words)), nil
+}
+
+// fillKeys stores the phonetic, anagram, rhyme & collation keys and the count of aksharas of the words which do not
+// have them, i.e. the words added before they were introduced or by other clients of the DB.
+func (lxc *LexiconSQL) fillKeys(ctx context.Context) error {
+	return lxc.fillKeysWhere(ctx, "l.phonetic IS NULL OR l.anagram IS NULL OR l.aksharas IS NULL OR l.rhyme IS NULL OR l.sort_key IS NULL")
+}
+
+// fillSortKeys stores the keys of the words which do not have a collation key, see fillKeys. Unlike the other keys
+// it is looked up by its index, so it is cheap enough for every search in the dictionary order.
+func (lxc *LexiconSQL) fillSortKeys(ctx context.Context) error {
+	return lxc.fillKeysWhere(ctx, "l.sort_key IS NULL")
+}
+
+// fillKeysWhere stores all the keys of the words satisfying the predicate on the lexicon `l`.
+func (lxc *LexiconSQL) fillKeysWhere(ctx context.Context, predicate string) error {
+	words, err := lxc.wordsWhere(ctx, predicate)
+	if err != nil || len(words) == 0 {
+		return err
+	}
+
+	return lxc.inTx(ctx, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("UPDATE %s SET phonetic = %s, anagram = %s, aksharas = %s, rhyme = %s, sort_key = %s WHERE word = %s", tableName,
+			lxc.dialect.Placeholder(1), lxc.dialect.Placeholder(2), lxc.dialect.Placeholder(3), lxc.dialect.Placeholder(4), lxc.dialect.Placeholder(5),
+			lxc.dialect.Placeholder(6))
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
 		defer stmt.Close()
-		if _, err = stmt.Exec(vals...); err != nil {
+
+		for _, word := range words {
+			if _, err = stmt.ExecContext(ctx, phonetic.Key(word), anagram.Key(word), akshara.Count(word), rhyme.Key(word), collation.SortKey(word), word); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// wordsWhere returns all the words satisfying the predicate on the lexicon `l`, in no particular order.
+func (lxc *LexiconSQL) wordsWhere(ctx context.Context, predicate string) ([]string, error) {
+	res, err := lxc.db.QueryContext(ctx, fmt.Sprintf("SELECT l.word FROM %s l WHERE %s", tableName, predicate))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	words := make([]string, 0)
+	for res.Next() {
+		var word string
+		if err = res.Scan(&word); err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+
+	return words, res.Err()
+}
+
+func (lxc *LexiconSQL) Suggest(n int, words ...string) (*map[string][]string, error) {
+	return lxc.SuggestContext(context.Background(), n, words...)
+}
+
+func (lxc *LexiconSQL) SuggestContext(ctx context.Context, n int, words ...string) (*map[string][]string, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	} else if n < 1 {
+		return nil, errNoSuggestions
+	}
+
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	if err := lxc.buildSimilar(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, 0)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// the BK-tree holds every word, checking existence there saves a query per word
+		if _, ok := result[word]; !ok && !lxc.similar.Contains(word) {
+			result[word] = lxc.similar.Suggest(word, n)
+		}
+	}
+
+	return &result, nil
+}
+
+// buildSimilar reads every word of the lexicon into the BK-tree unless it is already built, caller must hold the lock.
+func (lxc *LexiconSQL) buildSimilar(ctx context.Context) error {
+	if lxc.similar != nil {
+		return nil
+	}
+
+	res, err := lxc.db.QueryContext(ctx, fmt.Sprintf("SELECT l.word FROM %s l", tableName))
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	similar := fuzzy.New()
+	for res.Next() {
+		var word string
+		if err = res.Scan(&word); err != nil {
 			return err
 		}
-	} else {
+		similar.Insert(word)
+	}
+
+	if err = res.Err(); err != nil {
 		return err
 	}
 
+	lxc.similar = similar
 	return nil
 }
 
+// updateSimilar applies the added and removed words to the BK-tree, if it is built.
+func (lxc *LexiconSQL) updateSimilar(added, removed []string) {
+	lxc.mu.Lock()
+	defer lxc.mu.Unlock()
+
+	if lxc.similar == nil {
+		return
+	}
+	for _, word := range removed {
+		lxc.similar.Remove(word)
+	}
+	for _, word := range added {
+		lxc.similar.Insert(word)
+	}
+}
+
+// searchSubString returns the page of the words matching the LIKE pattern `toSearch`, wildcards which are part
+// of the words must be escaped with Dialect.EscapeLike.
+func (lxc *LexiconSQL) searchSubString(ctx context.Context, options types.SearchOptions, toSearch string) ([]string, error) {
+	return lxc.searchWhere(ctx, options, lxc.dialect.Like("l.word", lxc.dialect.Placeholder(1)), toSearch)
+}
+
+// searchWhere returns the page of the words satisfying the predicate on the lexicon `l` in the order of the search
+// options, `args` are bound to the placeholders of the predicate. The DB orders the words and takes the page.
+func (lxc *LexiconSQL) searchWhere(ctx context.Context, options types.SearchOptions, predicate string, args ...interface{}) ([]string, error) {
+	predicate, args, err := lxc.filtered(ctx, options, predicate, args)
+	if err != nil {
+		return []string{}, err
+	}
+
+	query, args, err := lxc.selectWords(ctx, options, predicate, args)
+	if err != nil {
+		return []string{}, err
+	}
+
+	if page := lxc.dialect.Limit(options.Limit, options.Offset); len(page) != 0 {
+		query += " " + page
+	}
+
+	res, err := lxc.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return []string{}, err
+	}
+	defer res.Close()
+
+	words := make([]string, 0)
+	for res.Next() {
+		var word string
+		if err = res.Scan(&word); err != nil {
+			return []string{}, err
+		}
+
+		words = append(words, word)
+	}
+
+	if err = res.Err(); err != nil {
+		return []string{}, err
+	}
+
+	return words, nil
+}
+
+// selectWords returns the query of the words satisfying the predicate on the lexicon `l` which follow the cursor of
+// the search options, in the order of the options, along with its arguments. The limit & offset of the
+// page are left to the caller. In the dictionary order the words are ordered by their collation keys, which are stored
+// first, see fillSortKeys.
+func (lxc *LexiconSQL) selectWords(ctx context.Context, options types.SearchOptions, predicate string, args []interface{}) (string, []interface{}, error) {
+
+	var orderBy string
+	switch options.Order {
+	case types.OrderCodepoint:
+		orderBy = lxc.dialect.Binary("l.word")
+		if len(options.After) != 0 {
+			args = append(args, options.After)
+			predicate += fmt.Sprintf(" AND %s > %s", lxc.dialect.Binary("l.word"), lxc.dialect.Placeholder(len(args)))
+		}
+	case types.OrderStorage:
+		// words which are the same as per the collation are ordered by their characters
+		orderBy = lxc.dialect.Collate("l.word") + ", l.word"
+		if len(options.After) != 0 {
+			args = append(args, options.After)
+			predicate += " AND " + lxc.dialect.After("l.word", lxc.dialect.Placeholder(len(args)))
+		}
+	default:
+		if err := lxc.fillSortKeys(ctx); err != nil {
+			return "", nil, err
+		}
+
+		// words which are the same except for nukta & joiners have the same key, they are ordered by their code points
+		orderBy = "l.sort_key, " + lxc.dialect.Binary("l.word")
+		if len(options.After) != 0 {
+			key := collation.SortKey(options.After)
+			args = append(args, key, key, options.After)
+			predicate += fmt.Sprintf(" AND (l.sort_key > %s OR (l.sort_key = %s AND %s > %s))", lxc.dialect.Placeholder(len(args)-2),
+				lxc.dialect.Placeholder(len(args)-1), lxc.dialect.Binary("l.word"), lxc.dialect.Placeholder(len(args)))
+		}
+	}
+
+	return fmt.Sprintf("SELECT l.word FROM %s l WHERE %s ORDER BY %s", tableName, predicate, orderBy), args, nil
+}
+
+// filtered returns the predicate narrowed down to the words within the limits of the search options,
+// leaving out the excluded words, along with its arguments. The page of the options is left to the caller.
+// Counts of aksharas are stored first if the options limit them, see fillKeys.
+func (lxc *LexiconSQL) filtered(ctx context.Context, options types.SearchOptions, predicate string, args []interface{}) (string, []interface{}, error) {
+	if options.CountsAksharas() {
+		if err := lxc.fillKeys(ctx); err != nil {
+			return "", nil, err
+		}
+	}
+
+	conditions := []string{"(" + predicate + ")"}
+	limit := func(expression, operator string, value int) {
+		if value > 0 {
+			args = append(args, value)
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", expression, operator, lxc.dialect.Placeholder(len(args))))
+		}
+	}
+	limit("l.aksharas", ">=", options.MinAksharas)
+	limit("l.aksharas", "<=", options.MaxAksharas)
+	limit(lxc.dialect.Length("l.word"), ">=", options.MinLength)
+	limit(lxc.dialect.Length("l.word"), "<=", options.MaxLength)
+
+	if len(options.Exclude) != 0 {
+		conditions = append(conditions, fmt.Sprintf("l.word NOT IN (%s)", placeholders(lxc.dialect, len(args)+1, len(options.Exclude))))
+		args = append(args, asArgs(options.Exclude)...)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+func (lxc *LexiconSQL) Add(words ...string) (*types.AddResult, error) {
+	return lxc.AddContext(context.Background(), words...)
+}
+
+func (lxc *LexiconSQL) AddContext(ctx context.Context, words ...string) (*types.AddResult, error) {
+	return lxc.addAll(ctx, nil, words)
+}
+
+// addAll adds the words batch by batch, see Add, the progress is reported after every batch.
+func (lxc *LexiconSQL) addAll(ctx context.Context, progress types.ProgressFunc, words []string) (*types.AddResult, error) {
+	if len(words) == 0 {
+		return nil, errNilOrEmptyWords
+	}
+
+	if lxc.atomicAdd {
+		var result *types.AddResult
+		err := lxc.inTx(ctx, func(tx *sql.Tx) (err error) {
+			result, err = lxc.addInTx(ctx, tx, progress, words)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		lxc.updateSimilar(result.Inserted, nil)
+		return result, nil
+	}
+
+	result, toAdd := types.PrepareAdd(words)
+	for start := 0; start < len(toAdd); start += lxc.batchSize {
+		end := min(start+lxc.batchSize, len(toAdd))
+		var inserted, existing []string
+		err := lxc.inTx(ctx, func(tx *sql.Tx) (err error) {
+			inserted, existing, err = lxc.insert(ctx, tx, toAdd[start:end])
+			return err
+		})
+		if err != nil {
+			lxc.updateSimilar(result.Inserted, nil)
+			return result, err
+		}
+
+		result.Inserted, result.Existing = append(result.Inserted, inserted...), append(result.Existing, existing...)
+		progress.Report(end, len(toAdd))
+	}
+
+	lxc.updateSimilar(result.Inserted, nil)
+	return result, nil
+}
+
+func (lxc *LexiconSQL) AddStream(next func() ([]string, error), consume func(result *types.AddResult), progress types.ProgressFunc) error {
+	return lxc.AddStreamContext(context.Background(), next, consume, progress)
+}
+
+func (lxc *LexiconSQL) AddStreamContext(ctx context.Context, next func() ([]string, error), consume func(result *types.AddResult), progress types.ProgressFunc) error {
+	if !lxc.atomicAdd {
+		return types.AddStream(ctx, lxc.addAll, next, consume, progress)
+	}
+
+	// all the chunks are added in a single transaction, the BK-tree is updated chunk by chunk and rebuilt by the
+	// next similarity search if the transaction is rolled back
+	err := lxc.inTx(ctx, func(tx *sql.Tx) error {
+		return types.AddStream(ctx, func(ctx context.Context, progress types.ProgressFunc, words []string) (*types.AddResult, error) {
+			result, err := lxc.addInTx(ctx, tx, progress, words)
+			if err == nil {
+				lxc.updateSimilar(result.Inserted, nil)
+			}
+			return result, err
+		}, next, consume, progress)
+	})
+	if err != nil {
+		lxc.mu.Lock()
+		lxc.similar = nil
+		lxc.mu.Unlock()
+	}
+
+	return err
+}
+
+// addInTx adds the words batch by batch within the transaction, see Add, the progress is reported after every batch.
+func (lxc *LexiconSQL) addInTx(ctx context.Context, tx *sql.Tx, progress types.ProgressFunc, words []string) (*types.AddResult, error) {
+	result, toAdd := types.PrepareAdd(words)
+	for start := 0; start < len(toAdd); start += lxc.batchSize {
+		end := min(start+lxc.batchSize, len(toAdd))
+		inserted, existing, err := lxc.insert(ctx, tx, toAdd[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		result.Inserted, result.Existing = append(result.Inserted, inserted...), append(result.Existing, existing...)
+		progress.Report(end, len(toAdd))
+	}
+
+	return result, nil
+}
+
+// inTx runs `fn` in a transaction which is committed if `fn` succeeds else it is rolled back.
+func (lxc *LexiconSQL) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := lxc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insert adds all the words using a single query, existing words are ignored.
+// It returns the words which were inserted and the words which already existed. The DB decides which words exist
+// as per the collation of the word column, e.g. case insensitively, so the words stored by the query are read back
+// rather than compared here. A word is inserted only if it is stored as given and was not stored before the query,
+// words which are the same as per the collation as a stored word or another word of the batch are existing.
+func (lxc *LexiconSQL) insert(ctx context.Context, tx *sql.Tx, words []string) (inserted, existing []string, err error) {
+	before, err := lxc.existing(ctx, tx, words)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := lxc.dialect.InsertIgnore(tableName, columns, len(words))
+	if _, err = tx.ExecContext(ctx, query, asRows(words)...); err != nil {
+		return nil, nil, err
+	}
+
+	after, err := lxc.existing(ctx, tx, words)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, word := range words {
+		_, stored := after[word]
+		if _, present := before[word]; stored && !present {
+			inserted = append(inserted, word)
+		} else {
+			existing = append(existing, word)
+		}
+	}
+
+	return inserted, existing, nil
+}
+
+// existing returns the set of stored words which are the same as any of the given words as per the DB, the words
+// are as stored, e.g. in a different case than the given words.
+func (lxc *LexiconSQL) existing(ctx context.Context, tx *sql.Tx, words []string) (map[string]struct{}, error) {
+	query := fmt.Sprintf("SELECT l.word FROM %s l WHERE l.word IN (%s)", tableName, placeholders(lxc.dialect, 1, len(words)))
+	res, err := tx.QueryContext(ctx, query, asArgs(words)...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	existing := make(map[string]struct{})
+	for res.Next() {
+		var word string
+		if err = res.Scan(&word); err != nil {
+			return nil, err
+		}
+
+		existing[word] = struct{}{}
+	}
+
+	return existing, res.Err()
+}
+
+func (lxc *LexiconSQL) Remove(words ...string) (int, error) {
+	return lxc.RemoveContext(context.Background(), words...)
+}
+
+func (lxc *LexiconSQL) RemoveContext(ctx context.Context, words ...string) (int, error) {
+	if len(words) == 0 {
+		return 0, errNilOrEmptyWords
+	}
+
+	removed := 0
+	for start := 0; start < len(words); start += lxc.batchSize {
+		batch := words[start:min(start+lxc.batchSize, len(words))]
+		deleted, err := lxc.delete(ctx, batch)
+		if err != nil {
+			return removed, err
+		}
+
+		removed += len(deleted)
+		if len(deleted) != 0 {
+			lxc.updateSimilar(nil, deleted)
+		}
+	}
+
+	return removed, nil
+}
+
+// delete removes all the words using a single query and returns the removed words as they were stored. The DB matches
+// the words as per the collation of the word column, e.g. case insensitively, so the stored words are read in the same
+// transaction before they are deleted.
+func (lxc *LexiconSQL) delete(ctx context.Context, words []string) ([]string, error) {
+	deleted := make([]string, 0, len(words))
+	err := lxc.inTx(ctx, func(tx *sql.Tx) error {
+		stored, err := lxc.existing(ctx, tx, words)
+		if err != nil || len(stored) == 0 {
+			return err
+		}
+
+		query := fmt.Sprintf("DELETE FROM %s WHERE word IN (%s)", tableName, placeholders(lxc.dialect, 1, len(words)))
+		if _, err = tx.ExecContext(ctx, query, asArgs(words)...); err != nil {
+			return err
+		}
+
+		for word := range stored {
+			deleted = append(deleted, word)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+func (lxc *LexiconSQL) Rewrite(fn func(word string) string) (int, error) {
+	return lxc.RewriteContext(context.Background(), fn)
+}
+
+func (lxc *LexiconSQL) RewriteContext(ctx context.Context, fn func(word string) string) (int, error) {
+	if fn == nil {
+		return 0, errNilRewrite
+	}
+
+	rewritten := 0
+	err := lxc.inTx(ctx, func(tx *sql.Tx) error {
+		olds, news, err := lxc.rewrites(ctx, tx, fn)
+		if err != nil {
+			return err
+		}
+
+		// old forms are removed before the new ones are added so that a word which only differs in case
+		// is not mistaken for an existing word, new forms which already exist are stored once
+		for start := 0; start < len(olds); start += lxc.batchSize {
+			batch := olds[start:min(start+lxc.batchSize, len(olds))]
+			query := fmt.Sprintf("DELETE FROM %s WHERE word IN (%s)", tableName, placeholders(lxc.dialect, 1, len(batch)))
+			if _, err = tx.ExecContext(ctx, query, asArgs(batch)...); err != nil {
+				return err
+			}
+		}
+
+		for start := 0; start < len(news); start += lxc.batchSize {
+			batch := news[start:min(start+lxc.batchSize, len(news))]
+			if _, err = tx.ExecContext(ctx, lxc.dialect.InsertIgnore(tableName, columns, len(batch)), asRows(batch)...); err != nil {
+				return err
+			}
+		}
+
+		rewritten = len(olds)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// words are rebuilt by the next similarity search
+	lxc.mu.Lock()
+	lxc.similar = nil
+	lxc.mu.Unlock()
+
+	return rewritten, nil
+}
+
+// rewrites reads every word of the lexicon and returns the words which are changed by `fn` along with
+// their distinct new forms.
+func (lxc *LexiconSQL) rewrites(ctx context.Context, tx *sql.Tx, fn func(word string) string) (olds, news []string, err error) {
+	res, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT l.word FROM %s l", tableName))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Close()
+
+	seen := make(map[string]struct{})
+	for res.Next() {
+		var word string
+		if err = res.Scan(&word); err != nil {
+			return nil, nil, err
+		}
+
+		rewritten := fn(word)
+		if rewritten == word {
+			continue
+		}
+
+		olds = append(olds, word)
+		if _, ok := seen[rewritten]; !ok && len(rewritten) != 0 {
+			seen[rewritten] = struct{}{}
+			news = append(news, rewritten)
+		}
+	}
+
+	return olds, news, res.Err()
+}
+
 func (lxc *LexiconSQL) Close() {
 	defer lxc.db.Close()
 }
+
+// asArgs converts the words to query arguments.
+func asArgs(words []string) []interface{} {
+	args := make([]interface{}, len(words))
+	for i, word := range words {
+		args[i] = word
+	}
+
+	return args
+}
+
+// asRows converts the words to query arguments of the rows of `columns`, every word along with its keys.
+func asRows(words []string) []interface{} {
+	args := make([]interface{}, 0, len(words)*len(columns))
+	for _, word := range words {
+		args = append(args, word, phonetic.Key(word), anagram.Key(word), akshara.Count(word), rhyme.Key(word), collation.SortKey(word))
+	}
+
+	return args
+}
@@ -2,23 +2,30 @@ package lexicon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mysql"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/rhyme"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/types"
 
 	"database/sql"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 	_ "github.com/libsql/libsql-client-go/libsql"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 const (
 	mysqlImage    = "mysql:8"
+	postgresImage = "postgres:16"
 	dbName        = "lexicons"
 	dbUserName    = "root"
 	dbPassword    = "toor"
@@ -51,8 +58,8 @@ func getMySQLDB(ctx context.Context) (*sql.DB, func()) {
 
 	// Add initial words to DB
 	db.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", dbName))
-	db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s(word VARCHAR(100) CHARACTER SET utf8 COLLATE utf8_unicode_ci NOT NULL, PRIMARY KEY (word))", testTableName))
-	query := fmt.Sprintf("INSERT INTO %s VALUES (?)", testTableName)
+	db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s(word VARCHAR(100) CHARACTER SET utf8 COLLATE utf8_unicode_ci NOT NULL, phonetic VARCHAR(200) CHARACTER SET utf8 COLLATE utf8_bin, anagram VARCHAR(200) CHARACTER SET utf8 COLLATE utf8_bin, aksharas INT, rhyme VARCHAR(200) CHARACTER SET utf8 COLLATE utf8_bin, sort_key VARCHAR(700) CHARACTER SET utf8 COLLATE utf8_bin, PRIMARY KEY (word))", testTableName))
+	query := fmt.Sprintf("INSERT INTO %s (word) VALUES (?)", testTableName)
 
 	for _, word := range randomWordsInsertedInDBOnInit {
 		if _, err := db.Exec(query, word); err != nil {
@@ -106,8 +113,8 @@ func getLibSQLDB(ctx context.Context) (*sql.DB, func()) {
 	}
 
 	// Add initial words to DB
-	db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s(word VARCHAR(100) collate NOCASE, PRIMARY KEY (word))", testTableName))
-	query := fmt.Sprintf("INSERT INTO %s VALUES (?)", testTableName)
+	db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s(word VARCHAR(100) collate NOCASE, phonetic VARCHAR(200), anagram VARCHAR(200), aksharas INT, rhyme VARCHAR(200), sort_key VARCHAR(700), PRIMARY KEY (word))", testTableName))
+	query := fmt.Sprintf("INSERT INTO %s (word) VALUES (?)", testTableName)
 
 	for _, word := range randomWordsInsertedInDBOnInit {
 		if _, err := db.Exec(query, word); err != nil {
@@ -131,19 +138,115 @@ func getLibSQLDB(ctx context.Context) (*sql.DB, func()) {
 	return db, closeFn
 }
 
+func getPostgresDB(ctx context.Context) (*sql.DB, func()) {
+	req := testcontainers.ContainerRequest{
+		Image:        postgresImage,
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_DB":       dbName,
+			"POSTGRES_USER":     dbUserName,
+			"POSTGRES_PASSWORD": dbPassword,
+		},
+		// the server restarts once after the initialisation, wait for the second start
+		WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+	}
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+
+	host, _ := container.Host(ctx)
+	port, _ := container.MappedPort(ctx, "5432/tcp")
+	db, err := sql.Open("postgres", fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", dbUserName, dbPassword, host, port.Int(), dbName))
+	if err != nil {
+		container.Terminate(ctx)
+		panic(err.Error())
+	}
+
+	// Add initial words to DB
+	db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s(word VARCHAR(100) NOT NULL, phonetic VARCHAR(200), anagram VARCHAR(200), aksharas INT, rhyme VARCHAR(200) COLLATE \"C\", sort_key VARCHAR(700) COLLATE \"C\", PRIMARY KEY (word))", testTableName))
+	// same trigram index as the migrations, the contains search must give the same words through it
+	db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm")
+	db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_word_trgm_idx ON %s USING gin (word gin_trgm_ops)", testTableName, testTableName))
+	query := fmt.Sprintf("INSERT INTO %s (word) VALUES ($1)", testTableName)
+
+	for _, word := range randomWordsInsertedInDBOnInit {
+		if _, err := db.Exec(query, word); err != nil {
+			db.Close()
+			container.Terminate(ctx)
+			panic(err)
+		}
+	}
+
+	// Clean up the container
+	closeFn := func() {
+		if err := db.Close(); err != nil {
+			panic(err.Error())
+		}
+
+		if err := container.Terminate(ctx); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return db, closeFn
+}
+
+func getSQLiteDB() (*sql.DB, func()) {
+	return getSQLiteDBOf("sqlite3")
+}
+
+// getSQLiteDBOf opens the in memory SQLite DB through the driver, e.g. SQLiteDriver to match regular expressions natively.
+func getSQLiteDBOf(driver string) (*sql.DB, func()) {
+	// a single connection keeps the same in memory DB for the whole test
+	db, err := sql.Open(driver, ":memory:")
+	if err != nil {
+		panic(err.Error())
+	}
+	db.SetMaxOpenConns(1)
+
+	// Add initial words to DB
+	db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s(word VARCHAR(100) collate NOCASE, phonetic VARCHAR(200), anagram VARCHAR(200), aksharas INT, rhyme VARCHAR(200), sort_key VARCHAR(700), PRIMARY KEY (word))", testTableName))
+	query := fmt.Sprintf("INSERT INTO %s (word) VALUES (?)", testTableName)
+
+	for _, word := range randomWordsInsertedInDBOnInit {
+		if _, err := db.Exec(query, word); err != nil {
+			db.Close()
+			panic(err)
+		}
+	}
+
+	closeFn := func() {
+		if err := db.Close(); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return db, closeFn
+}
+
 func TestLexiconWithDB_Lookup(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
 	mysqlDB, closeDB := getMySQLDB(ctx)
 	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
 
 	defer cancelCtx()
 	defer closeDB()
 	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
 
 	type fields struct {
-		mySQL  *sql.DB
-		libSQL *sql.DB
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
 	}
 	type args struct {
 		words []string
@@ -157,35 +260,35 @@ func TestLexiconWithDB_Lookup(t *testing.T) {
 	}{
 		{
 			name:    "Given a Lexicon with some words, when Lookup is invoked for the existing word, then return true should be returned",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{words: []string{"नमस्ते"}},
 			want:    &([]string{"नमस्ते"}),
 			wantErr: false,
 		},
 		{
 			name:    "Given a Lexicon with some words, when Lookup is invoked for that non existing word, then return value should be false",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{words: []string{"notexists"}},
 			want:    &([]string{}),
 			wantErr: false,
 		},
 		{
 			name:    "Given a Lexicon with some words, when Lookup is invoked multiple words some exists and others don't, then return value should be true only for existing words",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{words: []string{"नमस्कार", "notexists", "सुंदर", "धन्यवाद", "पराक्रम", "पानी"}},
 			want:    &([]string{"नमस्कार", "सुंदर", "धन्यवाद"}),
 			wantErr: false,
 		},
 		{
 			name:    "Given a Lexicon with some words, when Lookup is invoked for nil words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{words: nil},
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			name:    "Given a Lexicon with some words, when Lookup is invoked for empty words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{words: []string{}},
 			want:    nil,
 			wantErr: true,
@@ -195,7 +298,7 @@ func TestLexiconWithDB_Lookup(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			test := func(db *sql.DB, dbName string) {
-				lxc := &LexiconSQL{db, dbName}
+				lxc := Open(db, dbName)
 				got, err := lxc.Lookup(tt.args.words...)
 				if (err != nil) != tt.wantErr {
 					t.Errorf("[%s] LexiconWithDB.Lookup() error = %v, wantErr %v", dbName, err, tt.wantErr)
@@ -208,6 +311,8 @@ func TestLexiconWithDB_Lookup(t *testing.T) {
 
 			test(tt.fields.mySQL, "mysql")
 			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
 		})
 	}
 }
@@ -217,14 +322,20 @@ func TestLexiconWithDB_GetAllWordsStartingWith(t *testing.T) {
 	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
 	mysqlDB, closeDB := getMySQLDB(ctx)
 	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
 
 	defer cancelCtx()
 	defer closeDB()
 	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
 
 	type fields struct {
-		mySQL  *sql.DB
-		libSQL *sql.DB
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
 	}
 
 	type args struct {
@@ -240,7 +351,7 @@ func TestLexiconWithDB_GetAllWordsStartingWith(t *testing.T) {
 	}{
 		{
 			name:   "Given a Lexicon with some words, when SearchStartsWith is invoked for existing word, then return all the words starting with the substring sorted lexicographically",
-			fields: fields{mysqlDB, libsqlDB},
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:   args{substrings: []string{"न"}},
 			want: &(map[string][]string{
 				"न": {"नमस्कार", "नमस्ते"},
@@ -249,14 +360,14 @@ func TestLexiconWithDB_GetAllWordsStartingWith(t *testing.T) {
 		},
 		{
 			name:    "Given a Lexicon with some words, when SearchStartsWith is invoked for non-existing word, then return no response for the substring",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{substrings: []string{"क्र"}},
 			want:    &map[string][]string{},
 			wantErr: false,
 		},
 		{
 			name:   "Given a Lexicon with some words, when SearchStartsWith is invoked for mix of existing & non existing word, then return all the words starting with the existing words mapped to correct key sorted lexicographically while non existing words have no entry",
-			fields: fields{mysqlDB, libsqlDB},
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:   args{substrings: []string{"नम", "somethingelse", "नमस्", "धन्य"}},
 			want: &(map[string][]string{
 				"नम":   {"नमस्कार", "नमस्ते"},
@@ -267,14 +378,14 @@ func TestLexiconWithDB_GetAllWordsStartingWith(t *testing.T) {
 		},
 		{
 			name:    "Given a Lexicon with some words, when SearchStartsWith is invoked for nil words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{substrings: nil},
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			name:    "Given a Lexicon with some words, when SearchStartsWith is invoked for empty words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{substrings: []string{}},
 			want:    nil,
 			wantErr: true,
@@ -284,7 +395,7 @@ func TestLexiconWithDB_GetAllWordsStartingWith(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			test := func(db *sql.DB, dbName string) {
-				lxc := &LexiconSQL{db, dbName}
+				lxc := Open(db, dbName)
 				got, err := lxc.GetAllWordsStartingWith(tt.args.substrings...)
 				if (err != nil) != tt.wantErr {
 					t.Errorf("LexiconWithDB.GetAllWordsStartingWith() error = %v, wantErr %v", err, tt.wantErr)
@@ -297,6 +408,8 @@ func TestLexiconWithDB_GetAllWordsStartingWith(t *testing.T) {
 
 			test(tt.fields.mySQL, "mysql")
 			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
 		})
 	}
 }
@@ -306,14 +419,20 @@ func TestLexiconWithDB_GetAllWordsEndingWith(t *testing.T) {
 	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
 	mysqlDB, closeDB := getMySQLDB(ctx)
 	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
 
 	defer cancelCtx()
 	defer closeDB()
 	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
 
 	type fields struct {
-		mySQL  *sql.DB
-		libSQL *sql.DB
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
 	}
 	type args struct {
 		substrings []string
@@ -327,7 +446,7 @@ func TestLexiconWithDB_GetAllWordsEndingWith(t *testing.T) {
 	}{
 		{
 			name:   "Given a Lexicon with some words, when SearchEndsWith is invoked for existing word, then return all the words ending with the substring sorted lexicographically",
-			fields: fields{mysqlDB, libsqlDB},
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:   args{substrings: []string{"र"}},
 			want: &(map[string][]string{
 				"र": {"नमस्कार", "सुंदर"},
@@ -336,14 +455,14 @@ func TestLexiconWithDB_GetAllWordsEndingWith(t *testing.T) {
 		},
 		{
 			name:    "Given a Lexicon with some words, when SearchEndsWith is invoked for non-existing word, then return no response for the substring",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{substrings: []string{"क्र"}},
 			want:    &map[string][]string{},
 			wantErr: false,
 		},
 		{
 			name:   "Given a Lexicon with some words, when SearchEndsWith is invoked for mix of existing & non existing word, then return all the words ending with the existing words mapped to correct key sorted lexicographically while non existing words have no entry",
-			fields: fields{mysqlDB, libsqlDB},
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:   args{substrings: []string{"र", "somethingelse", "क्ष", "वाद"}},
 			want: &(map[string][]string{
 				"र":   {"नमस्कार", "सुंदर"},
@@ -354,14 +473,14 @@ func TestLexiconWithDB_GetAllWordsEndingWith(t *testing.T) {
 		},
 		{
 			name:    "Given a Lexicon with some words, when SearchEndsWith is invoked for nil words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{substrings: nil},
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			name:    "Given a Lexicon with some words, when SearchEndsWith is invoked for empty words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
 			args:    args{substrings: []string{}},
 			want:    nil,
 			wantErr: true,
@@ -371,7 +490,7 @@ func TestLexiconWithDB_GetAllWordsEndingWith(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			test := func(db *sql.DB, dbName string) {
-				lxc := &LexiconSQL{db, dbName}
+				lxc := Open(db, dbName)
 				got, err := lxc.GetAllWordsEndingWith(tt.args.substrings...)
 				if (err != nil) != tt.wantErr {
 					t.Errorf("LexiconWithDB.GetAllWordsEndingWith() error = %v, wantErr %v", err, tt.wantErr)
@@ -384,61 +503,334 @@ func TestLexiconWithDB_GetAllWordsEndingWith(t *testing.T) {
 
 			test(tt.fields.mySQL, "mysql")
 			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
 		})
 	}
 }
 
-func TestLexiconWithDB_Add(t *testing.T) {
+func TestLexiconWithDB_GetAllWordsContaining(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
 	mysqlDB, closeDB := getMySQLDB(ctx)
 	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
 
 	defer cancelCtx()
 	defer closeDB()
 	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
 
 	type fields struct {
-		mySQL  *sql.DB
-		libSQL *sql.DB
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
 	}
 	type args struct {
-		words []string
+		substrings []string
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
+		want    *map[string][]string
 		wantErr bool
 	}{
 		{
-			name:    "Given a Lexicon with some words, when Add is invoked with empty array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
-			args:    args{words: []string{}},
+			name:   "Given a Lexicon with some words, when SearchContaining is invoked for existing word, then return all the words containing the substring sorted lexicographically",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:   args{substrings: []string{"मस्"}},
+			want: &(map[string][]string{
+				"मस्": {"नमस्कार", "नमस्ते"},
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked for non-existing word, then return no response for the substring",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{substrings: []string{"क्र"}},
+			want:    &map[string][]string{},
+			wantErr: false,
+		},
+		{
+			name:   "Given a Lexicon with some words, when SearchContaining is invoked for mix of existing & non existing word, then return all the words containing the existing words mapped to correct key sorted lexicographically while non existing words have no entry",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:   args{substrings: []string{"स्", "somethingelse", "क्ष", "न्य"}},
+			want: &(map[string][]string{
+				"स्":  {"नमस्कार", "नमस्ते"},
+				"क्ष": {"मोक्ष"},
+				"न्य": {"धन्यवाद"},
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked for nil words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{substrings: nil},
+			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "Given a Lexicon with some words, when Add is invoked on a new non existent word, then no error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
-			args:    args{words: []string{"देव"}},
+			name:    "Given a Lexicon with some words, when SearchContaining is invoked for empty words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{substrings: []string{}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := func(db *sql.DB, dbName string) {
+				lxc := Open(db, dbName)
+				got, err := lxc.GetAllWordsContaining(tt.args.substrings...)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("LexiconWithDB.GetAllWordsContaining() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("LexiconWithDB.GetAllWordsContaining() = %v, want %v", got, tt.want)
+				}
+			}
+
+			test(tt.fields.mySQL, "mysql")
+			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
+		})
+	}
+}
+
+func TestLexiconWithDB_GetAllWordsOfAksharaCount(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		// words inserted on init have no count of aksharas, they are filled by the first search
+		lxc := Open(db, dbName)
+		want := &(map[string][]string{"न": {"नमस्ते"}, "स": {"सुंदर"}})
+		got, err := lxc.GetAllWordsOfAksharaCount(3, "न", "स", "ध", "somethingelse")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsOfAksharaCount() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsOfAksharaCount() = %v, want %v", dbName, got, want)
+		}
+
+		// a prefix with LIKE wildcards is matched literally
+		want = &map[string][]string{}
+		got, _ = lxc.GetAllWordsOfAksharaCount(4, "%", "_")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsOfAksharaCount() = %v, want %v", dbName, got, want)
+		}
+
+		if _, err = lxc.GetAllWordsOfAksharaCount(0, "न"); err == nil {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsOfAksharaCount() error = nil, want error for count less than 1", dbName)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_GetAllWordsMatching(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	type fields struct {
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
+	}
+	type args struct {
+		substrings []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *map[string][]string
+		wantErr bool
+	}{
+		{
+			name:   "Given a Lexicon with some words, when SearchPattern is invoked for existing word, then return all the words matching the pattern sorted lexicographically",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:   args{substrings: []string{"न*"}},
+			want: &(map[string][]string{
+				"न*": {"नमस्कार", "नमस्ते"},
+			}),
 			wantErr: false,
 		},
 		{
-			name:    "Given a Lexicon with some words, when Add is invoked on existing word, then error should be suppressed",
-			fields:  fields{mysqlDB, libsqlDB},
-			args:    args{words: []string{"नमस्कार"}},
+			name:    "Given a Lexicon with some words, when SearchPattern is invoked for non-existing word, then return no response for the substring",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{substrings: []string{"क्र"}},
+			want:    &map[string][]string{},
 			wantErr: false,
 		},
 		{
-			name:    "Given a Lexicon with some words, when Add is invoked for nil words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
-			args:    args{words: nil},
+			name:   "Given a Lexicon with some words, when SearchPattern is invoked for mix of existing & non existing word, then return all the words matching the existing patterns mapped to correct key sorted lexicographically while non existing words have no entry",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:   args{substrings: []string{"न?स्?ार", "somethingelse", "??", "*वाद"}},
+			want: &(map[string][]string{
+				"न?स्?ार": {"नमस्कार"},
+				"??":      {"मोक्ष"},
+				"*वाद":    {"धन्यवाद"},
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchPattern is invoked for nil words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{substrings: nil},
+			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "Given a Lexicon with some words, when Add is invoked for empty words array, then error is expected",
-			fields:  fields{mysqlDB, libsqlDB},
-			args:    args{words: []string{}},
+			name:    "Given a Lexicon with some words, when SearchPattern is invoked for empty words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{substrings: []string{}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := func(db *sql.DB, dbName string) {
+				lxc := Open(db, dbName)
+				got, err := lxc.GetAllWordsMatching(tt.args.substrings...)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("LexiconWithDB.GetAllWordsMatching() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("LexiconWithDB.GetAllWordsMatching() = %v, want %v", got, tt.want)
+				}
+			}
+
+			test(tt.fields.mySQL, "mysql")
+			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
+		})
+	}
+}
+
+func TestLexiconWithDB_GetAllWordsMatchingRegexp(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+	sqliteRegexpDB, closeDB5 := getSQLiteDBOf(SQLiteDriver)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+	defer closeDB5()
+
+	type fields struct {
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
+		// SQLite with the `regexp` function, the expressions are matched by the server as well
+		sqliteRegexp *sql.DB
+	}
+	type args struct {
+		substrings []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *map[string][]string
+		wantErr bool
+	}{
+		{
+			name:   "Given a Lexicon with some words, when SearchRegexp is invoked for existing word, then return all the words matching the expression sorted lexicographically",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:   args{substrings: []string{"^न"}},
+			want: &(map[string][]string{
+				"^न": {"नमस्कार", "नमस्ते"},
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchRegexp is invoked for non-existing word, then return no response for the substring",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:    args{substrings: []string{"क्र"}},
+			want:    &map[string][]string{},
+			wantErr: false,
+		},
+		{
+			name:   "Given a Lexicon with some words, when SearchRegexp is invoked for mix of existing & non existing word, then return all the words matching the existing expressions mapped to correct key sorted lexicographically while non existing words have no entry",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:   args{substrings: []string{"्[कष]", "somethingelse", "वाद$"}},
+			want: &(map[string][]string{
+				"्[कष]": {"नमस्कार", "मोक्ष"},
+				"वाद$":  {"धन्यवाद"},
+			}),
+			wantErr: false,
+		},
+		{
+			name:   "Given a Lexicon with some words, when SearchRegexp is invoked with ASCII only classes, then every Devanagari word is expected whatever the server",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:   args{substrings: []string{`^\W+$`, `\w`}},
+			want: &(map[string][]string{
+				`^\W+$`: {"धन्यवाद", "नमस्कार", "नमस्ते", "मोक्ष", "सुंदर"},
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchRegexp is invoked for an invalid expression, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:    args{substrings: []string{"[क"}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchRegexp is invoked for nil words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:    args{substrings: nil},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when SearchRegexp is invoked for empty words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB, sqliteRegexpDB},
+			args:    args{substrings: []string{}},
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -446,14 +838,951 @@ func TestLexiconWithDB_Add(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			test := func(db *sql.DB, dbName string) {
-				lxc := &LexiconSQL{db, dbName}
-				if err := lxc.Add(tt.args.words...); (err != nil) != tt.wantErr {
-					t.Errorf("LexiconWithDB.Add() error = %v, wantErr %v", err, tt.wantErr)
+				lxc := Open(db, dbName)
+				got, err := lxc.GetAllWordsMatchingRegexp(tt.args.substrings...)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("LexiconWithDB.GetAllWordsMatchingRegexp() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("LexiconWithDB.GetAllWordsMatchingRegexp() = %v, want %v", got, tt.want)
 				}
 			}
 
 			test(tt.fields.mySQL, "mysql")
 			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
+			test(tt.fields.sqliteRegexp, SQLiteDriver)
 		})
 	}
 }
+
+func TestLexiconWithDB_GetAllWordsSimilarTo(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		want := &(map[string][]string{"नमस्कीर": {"नमस्कार"}, "नमस्त": {"नमस्ते"}})
+		got, err := lxc.GetAllWordsSimilarTo(1, "नमस्कीर", "नमस्त", "somethingelse")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsSimilarTo() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSimilarTo() = %v, want %v", dbName, got, want)
+		}
+
+		// words added and removed after the first search are seen by the next one
+		lxc.Add("नमस्कर")
+		lxc.Remove("नमस्ते")
+		defer lxc.Remove("नमस्कर")
+		defer lxc.Add("नमस्ते")
+
+		want = &(map[string][]string{"नमस्कीर": {"नमस्कर", "नमस्कार"}})
+		got, _ = lxc.GetAllWordsSimilarTo(1, "नमस्कीर", "नमस्त")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSimilarTo() after Add & Remove = %v, want %v", dbName, got, want)
+		}
+
+		if _, err = lxc.GetAllWordsSimilarTo(-1, "नमस्त"); err == nil {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSimilarTo() error = nil, want error for negative distance", dbName)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_GetAllWordsSoundingLike(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		// words inserted on init have no phonetic key, they are filled by the first search
+		lxc := Open(db, dbName)
+		want := &(map[string][]string{"नमसते": {"नमस्ते"}, "सुन्दर": {"सुंदर"}, "दन्यवाद": {"धन्यवाद"}})
+		got, err := lxc.GetAllWordsSoundingLike("नमसते", "सुन्दर", "दन्यवाद", "somethingelse")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsSoundingLike() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSoundingLike() = %v, want %v", dbName, got, want)
+		}
+
+		// words added through the lexicon are stored along with their phonetic key
+		lxc.Add("नमसते")
+		defer lxc.Remove("नमसते")
+
+		want = &(map[string][]string{"नमस्ते": {"नमसते", "नमस्ते"}})
+		got, _ = lxc.GetAllWordsSoundingLike("नमस्ते")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSoundingLike() after Add = %v, want %v", dbName, got, want)
+		}
+
+		// ृ is written as रि in the key, so the key of the longest word is longer than the word
+		long := strings.Repeat("कृ", types.MaxWordLength/2)
+		if added, err := lxc.Add(long); err != nil || len(added.Inserted) != 1 {
+			t.Fatalf("[%s] LexiconWithDB.Add() of the longest word with ृ = %v, %v", dbName, added, err)
+		}
+		defer lxc.Remove(long)
+
+		want = &(map[string][]string{long: {long}})
+		got, _ = lxc.GetAllWordsSoundingLike(long)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSoundingLike() of the longest word with ृ = %v, want %v", dbName, got, want)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_GetAllWordsRhymingWith(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		want := &(map[string][]string{"संस्कार": {"नमस्कार"}, "बंदर": {"सुंदर"}})
+		got, err := lxc.GetAllWordsRhymingWith(2, rhyme.Final, "संस्कार", "बंदर", "नमस्ते")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsRhymingWith() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsRhymingWith() = %v, want %v", dbName, got, want)
+		}
+
+		want = &(map[string][]string{"घर": {"धन्यवाद", "नमस्कार", "मोक्ष", "सुंदर"}})
+		got, _ = lxc.GetAllWordsRhymingWith(1, rhyme.Vowel, "घर")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsRhymingWith() = %v, want %v", dbName, got, want)
+		}
+
+		// words added through the lexicon are stored along with their rhyme key
+		lxc.Add("काल")
+		defer lxc.Remove("काल")
+
+		want = &(map[string][]string{"कार": {"काल", "धन्यवाद", "नमस्कार"}})
+		got, _ = lxc.GetAllWordsRhymingWith(2, rhyme.Vowel, "कार")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsRhymingWith() after Add = %v, want %v", dbName, got, want)
+		}
+
+		if _, err = lxc.GetAllWordsRhymingWith(0, rhyme.Final, "संस्कार"); err == nil {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsRhymingWith() error = nil, want error for no syllables", dbName)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_GetAllAnagrams(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		want := &(map[string][]string{"मनस्ते": {"नमस्ते"}, "रस्कानम": {"नमस्कार"}})
+		got, err := lxc.GetAllAnagramsOf("मनस्ते", "रस्कानम", "somethingelse")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllAnagramsOf() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllAnagramsOf() = %v, want %v", dbName, got, want)
+		}
+
+		// words added through the lexicon are stored along with their anagram key
+		lxc.Add("मनस्ते")
+		defer lxc.Remove("मनस्ते")
+
+		want = &(map[string][]string{"रस्तेनस्कामद": {"नमस्कार", "नमस्ते", "मनस्ते"}})
+		got, err = lxc.GetAllWordsFromTiles("रस्तेनस्कामद", "नस्ते")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsFromTiles() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsFromTiles() = %v, want %v", dbName, got, want)
+		}
+
+		if _, err = lxc.GetAllWordsFromTiles("कखगघङचछजझञटठडढणतथ"); err == nil {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsFromTiles() error = nil, want error for too many tiles", dbName)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_Suggest(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		want := &(map[string][]string{"सुन्दर": {"सुंदर"}, "नमसते": {"नमस्ते"}, "पुस्तक": {}})
+		got, err := lxc.Suggest(1, "सुन्दर", "नमसते", "नमस्ते", "पुस्तक")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.Suggest() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.Suggest() = %v, want %v", dbName, got, want)
+		}
+
+		if _, err = lxc.Suggest(0, "नमसते"); err == nil {
+			t.Errorf("[%s] LexiconWithDB.Suggest() error = nil, want error for no suggestions", dbName)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_SearchOptions(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		// words inserted on init have no count of aksharas, they are filled by the first search limiting it
+		lxc := Open(db, dbName)
+		options := types.SearchOptions{MinAksharas: 4}
+		want := &(map[string][]string{"्": {"धन्यवाद", "नमस्कार"}})
+		got, err := lxc.GetAllWordsContainingContext(ctx, options, "्")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsContainingContext() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsContainingContext() = %v, want %v", dbName, got, want)
+		}
+
+		// length is the count of characters, not bytes
+		options = types.SearchOptions{MinLength: 6, MaxLength: 6}
+		want = &(map[string][]string{"्": {"नमस्ते"}})
+		got, _ = lxc.GetAllWordsContainingContext(ctx, options, "्")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsContainingContext() = %v, want %v", dbName, got, want)
+		}
+
+		options = types.SearchOptions{MaxAksharas: 3, Exclude: []string{"नमस्ते"}}
+		want = &map[string][]string{}
+		got, _ = lxc.GetAllWordsStartingWithContext(ctx, options, "न")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsStartingWithContext() = %v, want %v", dbName, got, want)
+		}
+
+		want = &(map[string][]string{"मोक्षसुंदर": {"मोक्ष", "सुंदर"}})
+		got, _ = lxc.GetAllWordsFromTilesContext(ctx, options, "मोक्षसुंदर")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsFromTilesContext() = %v, want %v", dbName, got, want)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_Pagination(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		options := types.SearchOptions{Limit: 2}
+		want := &(map[string][]string{"्": {"धन्यवाद", "नमस्कार"}})
+		got, err := lxc.GetAllWordsContainingContext(ctx, options, "्")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsContainingContext() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsContainingContext() = %v, want %v", dbName, got, want)
+		}
+
+		// the next page starts after the cursor of the full page
+		options.After = options.Next((*got)["्"])
+		want = &(map[string][]string{"्": {"नमस्ते", "मोक्ष"}})
+		got, _ = lxc.GetAllWordsContainingContext(ctx, options, "्")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsContainingContext() = %v, want %v", dbName, got, want)
+		}
+
+		options = types.SearchOptions{Offset: 3}
+		want = &(map[string][]string{"्": {"मोक्ष"}})
+		got, _ = lxc.GetAllWordsContainingContext(ctx, options, "्")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsContainingContext() = %v, want %v", dbName, got, want)
+		}
+
+		// words matched after reading them are paged after matching
+		options = types.SearchOptions{Limit: 1, Offset: 1}
+		want = &(map[string][]string{"न*": {"नमस्ते"}})
+		got, _ = lxc.GetAllWordsMatchingContext(ctx, options, "न*")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsMatchingContext() = %v, want %v", dbName, got, want)
+		}
+
+		options = types.SearchOptions{Limit: 1, After: "धन्यवाद"}
+		want = &(map[string][]string{"न": {"नमस्कार"}})
+		got, _ = lxc.GetAllWordsMatchingRegexpContext(ctx, options, "न")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsMatchingRegexpContext() = %v, want %v", dbName, got, want)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_Order(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		if _, err := lxc.Add("साप", "संत", "सौर", "सत"); err != nil {
+			t.Fatalf("[%s] LexiconWithDB.Add() error = %v", dbName, err)
+		}
+
+		want := &(map[string][]string{"स": {"सत", "साप", "सुंदर", "सौर", "संत"}})
+		got, err := lxc.GetAllWordsStartingWithContext(ctx, types.SearchOptions{}, "स")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.GetAllWordsStartingWithContext() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsStartingWithContext() = %v, want %v", dbName, got, want)
+		}
+
+		// words stored without keys get their collation keys by the first search in the dictionary order
+		withoutKey := 0
+		if err = db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE sort_key IS NULL", testTableName)).Scan(&withoutKey); err != nil || withoutKey != 0 {
+			t.Errorf("[%s] count of words without collation key = %v, error = %v, want 0", dbName, withoutKey, err)
+		}
+
+		// the DB takes the page following the cursor in the dictionary order
+		options := types.SearchOptions{Limit: 2, After: "साप"}
+		want = &(map[string][]string{"स": {"सुंदर", "सौर"}})
+		got, _ = lxc.GetAllWordsStartingWithContext(ctx, options, "स")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsStartingWithContext() = %v, want %v", dbName, got, want)
+		}
+
+		options = types.SearchOptions{Order: types.OrderCodepoint}
+		want = &(map[string][]string{"स": {"संत", "सत", "साप", "सुंदर", "सौर"}})
+		got, _ = lxc.GetAllWordsStartingWithContext(ctx, options, "स")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsStartingWithContext() = %v, want %v", dbName, got, want)
+		}
+
+		options = types.SearchOptions{Order: types.OrderCodepoint, Limit: 2, After: "सत"}
+		want = &(map[string][]string{"स": {"साप", "सुंदर"}})
+		got, _ = lxc.GetAllWordsStartingWithContext(ctx, options, "स")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsStartingWithContext() = %v, want %v", dbName, got, want)
+		}
+
+		// words matched after reading them are read in the order following the cursor
+		options = types.SearchOptions{Limit: 2, After: "साप"}
+		want = &(map[string][]string{"^स": {"सुंदर", "सौर"}})
+		got, _ = lxc.GetAllWordsMatchingRegexpContext(ctx, options, "^स")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsMatchingRegexpContext() = %v, want %v", dbName, got, want)
+		}
+
+		// the DB takes the page in the storage order
+		options = types.SearchOptions{Order: types.OrderStorage, Limit: 2}
+		want = &(map[string][]string{"्": {"धन्यवाद", "नमस्कार"}})
+		got, _ = lxc.GetAllWordsContainingContext(ctx, options, "्")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsContainingContext() = %v, want %v", dbName, got, want)
+		}
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_Add(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	type fields struct {
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
+	}
+	type args struct {
+		words []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *types.AddResult
+		wantErr bool
+	}{
+		{
+			name:    "Given a Lexicon with some words, when Add is invoked with empty array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{}},
+			wantErr: true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Add is invoked on a new non existent word, then no error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{"देव"}},
+			want:    &types.AddResult{Inserted: []string{"देव"}, Existing: []string{}, Rejected: []types.RejectedWord{}},
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Add is invoked on existing word, then error should be suppressed",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{"नमस्कार"}},
+			want:    &types.AddResult{Inserted: []string{}, Existing: []string{"नमस्कार"}, Rejected: []types.RejectedWord{}},
+			wantErr: false,
+		},
+		{
+			name:   "Given a Lexicon with some words, when Add is invoked for mix of new, existing, repeated & blank words, then every word is reported under correct outcome",
+			fields: fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:   args{words: []string{"पानी", "सुंदर", "पानी", ""}},
+			want: &types.AddResult{
+				Inserted: []string{"पानी"},
+				Existing: []string{"पानी", "सुंदर"},
+				Rejected: []types.RejectedWord{{Word: "", Reason: "word is empty"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Add is invoked for nil words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: nil},
+			wantErr: true,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Add is invoked for empty words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := func(db *sql.DB, dbName string) {
+				lxc := Open(db, dbName)
+				got, err := lxc.Add(tt.args.words...)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("[%s] LexiconWithDB.Add() error = %v, wantErr %v", dbName, err, tt.wantErr)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("[%s] LexiconWithDB.Add() = %v, want %v", dbName, got, tt.want)
+				}
+			}
+
+			test(tt.fields.mySQL, "mysql")
+			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
+		})
+	}
+}
+
+func TestLexiconWithDB_AddVariants(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	// words differing only in case are the same word for the case insensitive word column of every DB but PostgreSQL
+	test := func(db *sql.DB, dbName string, caseInsensitive bool) {
+		lxc := Open(db, dbName)
+
+		want := &types.AddResult{Inserted: []string{"Abc", "abc"}, Existing: []string{}, Rejected: []types.RejectedWord{}}
+		if caseInsensitive {
+			want = &types.AddResult{Inserted: []string{"Abc"}, Existing: []string{"abc"}, Rejected: []types.RejectedWord{}}
+		}
+		got, err := lxc.Add("Abc", "abc")
+		if err != nil {
+			t.Fatalf("[%s] LexiconWithDB.Add() error = %v", dbName, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.Add() of variants within a batch = %v, want %v", dbName, got, want)
+		}
+
+		want = &types.AddResult{Inserted: []string{"ABC"}, Existing: []string{"abc"}, Rejected: []types.RejectedWord{}}
+		if caseInsensitive {
+			want = &types.AddResult{Inserted: []string{}, Existing: []string{"abc", "ABC"}, Rejected: []types.RejectedWord{}}
+		}
+		got, _ = lxc.Add("abc", "ABC")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.Add() of variants of stored words = %v, want %v", dbName, got, want)
+		}
+
+		lxc.Remove("Abc", "abc", "ABC")
+	}
+
+	test(mysqlDB, "mysql", true)
+	test(libsqlDB, "libsql", true)
+	test(sqliteDB, "sqlite3", true)
+	test(postgresDB, "postgres", false)
+}
+
+func TestLexiconWithDB_RemoveVariants(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	// the stored word removed through a variant must leave the words used by the similarity search as well
+	test := func(db *sql.DB, dbName string, caseInsensitive bool) {
+		lxc := Open(db, dbName)
+		lxc.Add("Abc")
+		lxc.GetAllWordsSimilarTo(1, "Abd") // builds the words used by the similarity search
+
+		wantRemoved, want := 0, &map[string][]string{"Abd": {"Abc"}}
+		if caseInsensitive {
+			wantRemoved, want = 1, &map[string][]string{}
+		}
+		if removed, err := lxc.Remove("ABC"); err != nil || removed != wantRemoved {
+			t.Errorf("[%s] LexiconWithDB.Remove() of a variant = %v, %v, want %v", dbName, removed, err, wantRemoved)
+		}
+		if got, _ := lxc.GetAllWordsSimilarTo(1, "Abd"); !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.GetAllWordsSimilarTo() after Remove() of a variant = %v, want %v", dbName, got, want)
+		}
+
+		lxc.Remove("Abc")
+	}
+
+	test(mysqlDB, "mysql", true)
+	test(libsqlDB, "libsql", true)
+	test(sqliteDB, "sqlite3", true)
+	test(postgresDB, "postgres", false)
+}
+
+func TestLexiconWithDB_Remove(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	type fields struct {
+		mySQL    *sql.DB
+		libSQL   *sql.DB
+		sqlite   *sql.DB
+		postgres *sql.DB
+	}
+	type args struct {
+		words []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "Given a Lexicon with some words, when Remove is invoked on a non existent word, then nothing is removed",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{"देव"}},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Remove is invoked for mix of existing & non existing words, then only existing words are removed and counted",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{"मोक्ष", "notexists", "सुंदर"}},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Remove is invoked on already removed word, then nothing is removed",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: []string{"मोक्ष"}},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "Given a Lexicon with some words, when Remove is invoked for nil words array, then error is expected",
+			fields:  fields{mysqlDB, libsqlDB, sqliteDB, postgresDB},
+			args:    args{words: nil},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := func(db *sql.DB, dbName string) {
+				lxc := Open(db, dbName)
+				got, err := lxc.Remove(tt.args.words...)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("[%s] LexiconWithDB.Remove() error = %v, wantErr %v", dbName, err, tt.wantErr)
+					return
+				}
+				if got != tt.want {
+					t.Errorf("[%s] LexiconWithDB.Remove() = %v, want %v", dbName, got, tt.want)
+				}
+			}
+
+			test(tt.fields.mySQL, "mysql")
+			test(tt.fields.libSQL, "libsql")
+			test(tt.fields.sqlite, "sqlite3")
+			test(tt.fields.postgres, "postgres")
+		})
+	}
+}
+
+func TestLexiconWithDB_AddInBatches(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	words := []string{"पानी", "नमस्ते", "देव", "पराक्रम", "कमल"}
+	want := &types.AddResult{
+		Inserted: []string{"पानी", "देव", "पराक्रम", "कमल"},
+		Existing: []string{"नमस्ते"},
+		Rejected: []types.RejectedWord{},
+	}
+	wantProgress := []int{2, 4, 5}
+
+	test := func(db *sql.DB, dbName string, atomic bool) {
+		lxc := Open(db, dbName, WithBatchSize(2), WithAtomicAdd(atomic))
+		got, err := lxc.Add(words...)
+		if err != nil {
+			t.Errorf("[%s] LexiconWithDB.Add() error = %v", dbName, err)
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.Add() = %v, want %v", dbName, got, want)
+		}
+		lxc.Remove(want.Inserted...)
+
+		// a single chunk of the stream is added batch by batch as well, reporting the progress of every batch
+		chunks := [][]string{words}
+		next := func() ([]string, error) {
+			if len(chunks) == 0 {
+				return nil, nil
+			}
+			chunk := chunks[0]
+			chunks = chunks[1:]
+			return chunk, nil
+		}
+
+		progress := make([]int, 0)
+		err = lxc.AddStream(next, func(result *types.AddResult) {
+			got = result
+		}, func(done, total int) {
+			progress = append(progress, done)
+		})
+		if err != nil {
+			t.Errorf("[%s] LexiconWithDB.AddStream() error = %v", dbName, err)
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.AddStream() = %v, want %v", dbName, got, want)
+		}
+		if !reflect.DeepEqual(progress, wantProgress) {
+			t.Errorf("[%s] LexiconWithDB.AddStream() progress = %v, want %v", dbName, progress, wantProgress)
+		}
+
+		// remove the added words so that the next round starts afresh
+		lxc.Remove(want.Inserted...)
+	}
+
+	for _, atomic := range []bool{false, true} {
+		test(mysqlDB, "mysql", atomic)
+		test(libsqlDB, "libsql", atomic)
+		test(sqliteDB, "sqlite3", atomic)
+		test(postgresDB, "postgres", atomic)
+	}
+}
+
+func TestLexiconWithDB_AddStream(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	failed := errors.New("input failed")
+
+	// the second chunk is followed by a failure of the input
+	test := func(db *sql.DB, dbName string, atomic bool) {
+		lxc := Open(db, dbName, WithBatchSize(2), WithAtomicAdd(atomic))
+		chunks := [][]string{{"पानी", "नमस्ते", "देव"}, {"कमल"}}
+		next := func() ([]string, error) {
+			if len(chunks) == 0 {
+				return nil, failed
+			}
+			chunk := chunks[0]
+			chunks = chunks[1:]
+			return chunk, nil
+		}
+
+		consumed := 0
+		err := lxc.AddStream(next, func(result *types.AddResult) {
+			consumed++
+		}, nil)
+		if !errors.Is(err, failed) {
+			t.Errorf("[%s] LexiconWithDB.AddStream() error = %v, want %v", dbName, err, failed)
+		}
+		if consumed != 2 {
+			t.Errorf("[%s] LexiconWithDB.AddStream() consumed %d chunks, want 2", dbName, consumed)
+		}
+
+		// atomic add rolls back the chunks added before the failure
+		want := &[]string{"पानी", "देव", "कमल"}
+		if atomic {
+			want = &[]string{}
+		}
+		got, _ := lxc.Lookup("पानी", "देव", "कमल")
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("[%s] LexiconWithDB.Lookup() after AddStream() = %v, want %v", dbName, got, want)
+		}
+
+		lxc.Remove("पानी", "देव", "कमल")
+	}
+
+	for _, atomic := range []bool{false, true} {
+		test(mysqlDB, "mysql", atomic)
+		test(libsqlDB, "libsql", atomic)
+		test(sqliteDB, "sqlite3", atomic)
+		test(postgresDB, "postgres", atomic)
+	}
+}
+
+func TestLexiconWithDB_Rewrite(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancelCtx := context.WithTimeout(ctx, 2*time.Minute)
+	mysqlDB, closeDB := getMySQLDB(ctx)
+	libsqlDB, closeDB2 := getLibSQLDB(ctx)
+	sqliteDB, closeDB3 := getSQLiteDB()
+	postgresDB, closeDB4 := getPostgresDB(ctx)
+
+	defer cancelCtx()
+	defer closeDB()
+	defer closeDB2()
+	defer closeDB3()
+	defer closeDB4()
+
+	// precomposed क़ (U+0958) is rewritten as क + nukta
+	toDecomposed := func(word string) string {
+		return strings.ReplaceAll(word, "\u0958", "\u0915\u093c")
+	}
+	want := &(map[string][]string{"\u0915\u093c": {"\u0915\u093cलम", "\u0915\u093cानून"}})
+
+	test := func(db *sql.DB, dbName string) {
+		lxc := Open(db, dbName)
+		lxc.Add("\u0958लम", "\u0958ानून")
+
+		got, err := lxc.Rewrite(toDecomposed)
+		if err != nil {
+			t.Errorf("[%s] LexiconWithDB.Rewrite() error = %v", dbName, err)
+			return
+		}
+		if got != 2 {
+			t.Errorf("[%s] LexiconWithDB.Rewrite() = %v, want %v", dbName, got, 2)
+		}
+
+		gotWords, _ := lxc.GetAllWordsStartingWith("\u0915\u093c")
+		if !reflect.DeepEqual(gotWords, want) {
+			t.Errorf("[%s] LexiconWithDB after Rewrite() = %v, want %v", dbName, gotWords, want)
+		}
+
+		// rewriting again changes nothing
+		if got, _ := lxc.Rewrite(toDecomposed); got != 0 {
+			t.Errorf("[%s] LexiconWithDB.Rewrite() again = %v, want %v", dbName, got, 0)
+		}
+
+		lxc.Remove((*want)["\u0915\u093c"]...)
+	}
+
+	test(mysqlDB, "mysql")
+	test(libsqlDB, "libsql")
+	test(sqliteDB, "sqlite3")
+	test(postgresDB, "postgres")
+}
+
+func TestLexiconWithDB_SearchErrors(t *testing.T) {
+	// a DB without the lexicon table fails every query, the failure must not be reported as no words or absent words
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		panic(err.Error())
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	lxc := Open(db, "sqlite3")
+	searches := map[string]func() (*map[string][]string, error){
+		"GetAllWordsStartingWith":   func() (*map[string][]string, error) { return lxc.GetAllWordsStartingWith("न") },
+		"GetAllWordsEndingWith":     func() (*map[string][]string, error) { return lxc.GetAllWordsEndingWith("न") },
+		"GetAllWordsContaining":     func() (*map[string][]string, error) { return lxc.GetAllWordsContaining("न") },
+		"GetAllWordsOfAksharaCount": func() (*map[string][]string, error) { return lxc.GetAllWordsOfAksharaCount(2, "न") },
+		"GetAllWordsMatching":       func() (*map[string][]string, error) { return lxc.GetAllWordsMatching("न*") },
+		"GetAllWordsMatchingRegexp": func() (*map[string][]string, error) { return lxc.GetAllWordsMatchingRegexp("^न") },
+		"GetAllWordsSoundingLike":   func() (*map[string][]string, error) { return lxc.GetAllWordsSoundingLike("नमस्ते") },
+		"GetAllWordsRhymingWith": func() (*map[string][]string, error) {
+			return lxc.GetAllWordsRhymingWith(2, rhyme.Vowel, "नमस्ते")
+		},
+		"GetAllAnagramsOf":     func() (*map[string][]string, error) { return lxc.GetAllAnagramsOf("नमस्ते") },
+		"GetAllWordsFromTiles": func() (*map[string][]string, error) { return lxc.GetAllWordsFromTiles("नमस्ते") },
+	}
+
+	for name, search := range searches {
+		if got, err := search(); err == nil {
+			t.Errorf("LexiconWithDB.%s() = %v, want error", name, got)
+		}
+	}
+
+	if got, err := lxc.Lookup("नमस्ते"); err == nil {
+		t.Errorf("LexiconWithDB.Lookup() = %v, want error", got)
+	}
+}
@@ -0,0 +1,30 @@
+package lexicon
+
+const (
+	// defaultBatchSize is the count of words written by a single query unless configured otherwise,
+	// it keeps the query well within MySQL `max_allowed_packet` default of 4MB.
+	defaultBatchSize = 1000
+)
+
+// An Option configures the LexiconSQL returned by Open.
+type Option func(lxc *LexiconSQL)
+
+// WithBatchSize sets the count of words written by a single query in bulk operations.
+// The size is capped so that a query stays within the count of bind parameters supported by the DB server,
+// zero or negative size is ignored.
+func WithBatchSize(size int) Option {
+	return func(lxc *LexiconSQL) {
+		if size > 0 {
+			lxc.batchSize = min(size, lxc.dialect.MaxBindParameters()/len(columns))
+		}
+	}
+}
+
+// WithAtomicAdd makes Add all-or-nothing, all the batches are written in a single transaction which is
+// rolled back if any of them fails. By default every batch is committed in its own transaction, so a
+// failure keeps the batches written before it.
+func WithAtomicAdd(atomic bool) Option {
+	return func(lxc *LexiconSQL) {
+		lxc.atomicAdd = atomic
+	}
+}
@@ -0,0 +1,95 @@
+package lexicon
+
+import (
+	"container/list"
+	"database/sql"
+	"regexp"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// SQLiteDriver is the database/sql driver name of SQLite with the `regexp` function registered on every
+	// connection, so `REGEXP` can be used in queries. SQLite has the operator but not the function behind it.
+	SQLiteDriver = "sqlite3_regexp"
+
+	// compiledSize is the count of the most recently used regular expressions kept compiled.
+	compiledSize = 64
+)
+
+var (
+	compiled = newRegexpCache(compiledSize) // regular expressions compiled by the `regexp` function
+)
+
+func init() {
+	sql.Register(SQLiteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("regexp", matchRegexp, true)
+		},
+	})
+}
+
+// matchRegexp is the `regexp` function of SQLite, `word REGEXP expr` invokes it as regexp(expr, word).
+// The expression is compiled once and reused for every row.
+func matchRegexp(expr, word string) (bool, error) {
+	re, err := compiled.get(expr)
+	if err != nil {
+		return false, err
+	}
+
+	return re.MatchString(word), nil
+}
+
+// regexpCache keeps the compiled regular expressions of the most recently used expressions, the least recently
+// used one is dropped once the cache is full so the expressions of a long running program do not pile up.
+type regexpCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List               // expressions, most recently used first
+	entries map[string]*list.Element // elements of `order` keyed by the expression
+}
+
+// regexpEntry is an element of the regexpCache.
+type regexpEntry struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+func newRegexpCache(size int) *regexpCache {
+	return &regexpCache{size: size, order: list.New(), entries: make(map[string]*list.Element, size)}
+}
+
+// get returns the compiled regular expression, compiling it only if it is not cached.
+func (c *regexpCache) get(expr string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if element, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(element)
+		c.mu.Unlock()
+		return element.Value.(*regexpEntry).re, nil
+	}
+	c.mu.Unlock()
+
+	// compiled outside the lock, another connection may compile the same expression meanwhile
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*regexpEntry).re, nil
+	}
+
+	c.entries[expr] = c.order.PushFront(&regexpEntry{expr: expr, re: re})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexpEntry).expr)
+	}
+
+	return re, nil
+}
@@ -0,0 +1,36 @@
+package lexicon
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRegexpCache_Get(t *testing.T) {
+	cache := newRegexpCache(2)
+
+	first, _ := cache.get("^क")
+	cache.get("^ख")
+	if again, _ := cache.get("^क"); again != first {
+		t.Errorf("regexpCache.get() compiled a cached expression again")
+	}
+
+	// ^ख is the least recently used expression, it is dropped for ^ग
+	cache.get("^ग")
+	if _, ok := cache.entries["^ख"]; ok || len(cache.entries) != 2 || cache.order.Len() != 2 {
+		t.Errorf("regexpCache.get() kept %d expressions, want ^क & ^ग", len(cache.entries))
+	}
+	if again, _ := cache.get("^क"); again != first {
+		t.Errorf("regexpCache.get() dropped the recently used expression")
+	}
+
+	if _, err := cache.get("("); err == nil {
+		t.Errorf("regexpCache.get() error = nil, want error for an invalid expression")
+	}
+
+	for i := 0; i < 10; i++ {
+		cache.get(fmt.Sprintf("^%d", i))
+	}
+	if len(cache.entries) != 2 || cache.order.Len() != 2 {
+		t.Errorf("regexpCache.get() kept %d expressions, want at most 2", len(cache.entries))
+	}
+}
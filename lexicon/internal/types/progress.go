@@ -0,0 +1,12 @@
+package types
+
+// A ProgressFunc is invoked by the bulk operations after every processed batch of words,
+// `done` is the count of words processed so far out of `total` words.
+type ProgressFunc func(done, total int)
+
+// Report invokes the ProgressFunc, a nil ProgressFunc reports nothing.
+func (fn ProgressFunc) Report(done, total int) {
+	if fn != nil {
+		fn(done, total)
+	}
+}
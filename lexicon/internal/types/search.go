@@ -0,0 +1,185 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/collation"
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+// Order is the order of the words returned by the searches.
+type Order string
+
+const (
+	OrderDictionary Order = "dictionary" // Devanagari dictionary order, following the varnamala, the default
+	OrderCodepoint  Order = "codepoint"  // order of the code points of the words
+	OrderStorage    Order = "db"         // order in which the storage returns the words, e.g. the collation of the database
+)
+
+// SearchOptions narrow down the words returned by the searches, a zero value of a limit means no limit.
+// Words of every searched string are paged separately, a page is the `Limit` words following the `After` cursor,
+// skipping `Offset` words.
+type SearchOptions struct {
+	MinAksharas int      // minimum count of aksharas of a word
+	MaxAksharas int      // maximum count of aksharas of a word
+	MinLength   int      // minimum count of characters (code points) of a word
+	MaxLength   int      // maximum count of characters (code points) of a word
+	Exclude     []string // words which are never returned
+	Limit       int      // maximum count of words returned for a searched string
+	Offset      int      // count of words skipped before the page
+	After       string   // cursor, the last word of the previous page, empty for the first page
+	Order       Order    // order of the words, empty for the dictionary order
+}
+
+// Validate checks that the limits are not negative and the minimums are not more than the maximums.
+func (o SearchOptions) Validate() error {
+	if o.MinAksharas < 0 || o.MaxAksharas < 0 || o.MinLength < 0 || o.MaxLength < 0 || o.Limit < 0 || o.Offset < 0 {
+		return errors.New("search limits must not be negative")
+	} else if o.MaxAksharas != 0 && o.MinAksharas > o.MaxAksharas {
+		return errors.New("minimum count of aksharas is more than the maximum")
+	} else if o.MaxLength != 0 && o.MinLength > o.MaxLength {
+		return errors.New("minimum length is more than the maximum")
+	} else if o.Order != "" && o.Order != OrderDictionary && o.Order != OrderCodepoint && o.Order != OrderStorage {
+		return fmt.Errorf("unknown order '%s'", o.Order)
+	}
+
+	return nil
+}
+
+// CountsAksharas checks if the options limit the count of aksharas of the words.
+func (o SearchOptions) CountsAksharas() bool {
+	return o.MinAksharas > 0 || o.MaxAksharas > 0
+}
+
+// Excludes checks if the word is one of the excluded words.
+func (o SearchOptions) Excludes(word string) bool {
+	return slices.Contains(o.Exclude, word)
+}
+
+// Keep checks if the word satisfies all of the options.
+func (o SearchOptions) Keep(word string) bool {
+	if length := utf8.RuneCountInString(word); length < o.MinLength || (o.MaxLength > 0 && length > o.MaxLength) {
+		return false
+	}
+
+	if o.CountsAksharas() {
+		if count := akshara.Count(word); count < o.MinAksharas || (o.MaxAksharas > 0 && count > o.MaxAksharas) {
+			return false
+		}
+	}
+
+	return !o.Excludes(word)
+}
+
+// Filter returns the words which satisfy all of the options, in the same order. The words are filtered in place.
+func (o SearchOptions) Filter(words []string) []string {
+	kept := words[:0]
+	for _, word := range words {
+		if o.Keep(word) {
+			kept = append(kept, word)
+		}
+	}
+
+	return kept
+}
+
+// Pages checks if the options ask for a page of the words rather than all of them.
+func (o SearchOptions) Pages() bool {
+	return o.Limit > 0 || o.Offset > 0 || len(o.After) != 0
+}
+
+// Page returns the page of the ordered words. Words up to the `After` cursor are skipped, if the cursor is not
+// one of the words, e.g. it was removed since, then the words which are not greater than it are skipped.
+func (o SearchOptions) Page(words []string) []string {
+	if len(o.After) != 0 {
+		if i := slices.Index(words, o.After); i >= 0 {
+			words = words[i+1:]
+		} else {
+			i = 0
+			for i < len(words) && o.Compare(words[i], o.After) <= 0 {
+				i++
+			}
+			words = words[i:]
+		}
+	}
+
+	words = words[min(o.Offset, len(words)):]
+	if o.Limit > 0 && len(words) > o.Limit {
+		words = words[:o.Limit]
+	}
+
+	return words
+}
+
+// PageRanked returns the page of the words ordered by their ranks, see SortRanked. Unlike Page the cursor must be
+// one of the words, else ErrCursorNotFound is returned, as the rank of a word which is not one of them is unknown.
+func (o SearchOptions) PageRanked(words []string) ([]string, error) {
+	if len(o.After) != 0 && !slices.Contains(words, o.After) {
+		return nil, ErrCursorNotFound
+	}
+
+	return o.Page(words), nil
+}
+
+// Next returns the cursor of the page following the given page of words, empty if the page is the last one.
+// A page which is full may still be followed by an empty page.
+func (o SearchOptions) Next(page []string) string {
+	if o.Limit == 0 || len(page) < o.Limit {
+		return ""
+	}
+
+	return page[len(page)-1]
+}
+
+// Ordered checks if the words are ordered by the searches rather than returned in the order of the storage.
+func (o SearchOptions) Ordered() bool {
+	return o.Order != OrderStorage
+}
+
+// Compare returns an integer comparing two words in the order, 0 if a == b, -1 if a < b, and +1 if a > b.
+// In the storage order the words are compared by their code points.
+func (o SearchOptions) Compare(a, b string) int {
+	switch {
+	case o.Order == "" || o.Order == OrderDictionary:
+		return collation.Compare(a, b)
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Sort sorts the words in the order, the words are left as they are for the storage order.
+func (o SearchOptions) Sort(words []string) []string {
+	switch o.Order {
+	case "", OrderDictionary:
+		collation.Sort(words)
+	case OrderCodepoint:
+		sort.Strings(words)
+	}
+
+	return words
+}
+
+// SortRanked sorts the words of the same rank in the order, the words must be ordered by their ranks, e.g. by the
+// distance of GetAllWordsSimilarTo, `ranks[i]` is the rank of `words[i]`.
+func (o SearchOptions) SortRanked(words []string, ranks []int) []string {
+	for start, end := 0, 0; start < len(words); start = end {
+		for end = start + 1; end < len(words) && ranks[end] == ranks[start]; end++ {
+		}
+		o.Sort(words[start:end])
+	}
+
+	return words
+}
+
+// Apply returns the page of the words which satisfy all of the options, in the order, see Filter, Sort & Page.
+func (o SearchOptions) Apply(words []string) []string {
+	return o.Page(o.Sort(o.Filter(words)))
+}
@@ -0,0 +1,150 @@
+// Package types holds the types shared by the Lexicon interface and its implementations.
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+const (
+	// MaxWordLength is the maximum number of characters (code points) in a word, it matches the size of
+	// the word column in the DB schema.
+	MaxWordLength = 100
+
+	// MaxRegexpMatches is the maximum number of words returned for a single regular expression, a broad
+	// expression could otherwise return the whole lexicon.
+	MaxRegexpMatches = 10000
+
+	// MaxTiles is the maximum number of aksharas in a set of tiles, words formed from the tiles are looked up
+	// for every sub-multiset of the tiles and their count doubles with every distinct tile.
+	MaxTiles = 16
+)
+
+var (
+	// ErrTooManyMatches is returned along with the results when a regular expression matched more than
+	// MaxRegexpMatches words, only the first MaxRegexpMatches words are returned for it.
+	ErrTooManyMatches = fmt.Errorf("regular expression matches more than %d words", MaxRegexpMatches)
+
+	// ErrTooManyTiles is returned when a set of tiles has more than MaxTiles aksharas.
+	ErrTooManyTiles = fmt.Errorf("tiles are more than %d aksharas", MaxTiles)
+
+	// ErrCursorNotFound is returned when the cursor of a search ranking its words, e.g. by their distance, is not one
+	// of the words, there is no place for it in the ranks so the pages have to start again from the first one.
+	ErrCursorNotFound = errors.New("cursor is not one of the ranked words, start again from the first page")
+)
+
+// An AddResult reports what happened to each of the words given to Add.
+// Words within a list are not guaranteed to be in the order in which they were given.
+type AddResult struct {
+	Inserted []string       `json:"inserted"` // words which were not present and are now added to the lexicon
+	Existing []string       `json:"existing"` // words which were already present in the lexicon, including repeated input words
+	Rejected []RejectedWord `json:"rejected"` // words which could not be added to the lexicon
+}
+
+// A RejectedWord is a word which could not be added to the lexicon along with the reason.
+type RejectedWord struct {
+	Word   string `json:"word"`
+	Reason string `json:"reason"`
+}
+
+// PrepareAdd validates the words to be added against the storage constraints.
+// It returns an AddResult which has the invalid words as rejected and the repeated words as existing,
+// along with the unique valid words in the given order, which are yet to be added.
+func PrepareAdd(words []string) (*AddResult, []string) {
+	result := &AddResult{
+		Inserted: make([]string, 0),
+		Existing: make([]string, 0),
+		Rejected: make([]RejectedWord, 0),
+	}
+
+	toAdd := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if reason := storageConstraint(word); len(reason) != 0 {
+			result.Rejected = append(result.Rejected, RejectedWord{word, reason})
+		} else if _, ok := seen[word]; ok {
+			result.Existing = append(result.Existing, word)
+		} else {
+			seen[word] = struct{}{}
+			toAdd = append(toAdd, word)
+		}
+	}
+
+	return result, toAdd
+}
+
+// AddStream adds the chunks of words returned by `next` using `add` until `next` returns no words, `consume` is
+// invoked with the AddResult of every chunk once it is added. Progress reported by `add` for a chunk is reported to
+// `progress` on top of the chunks added before it, so the total grows chunk by chunk as the words are read.
+// If `next`, `add` or the context fail then the error is returned, the chunks consumed before it stay added.
+func AddStream(ctx context.Context, add func(ctx context.Context, progress ProgressFunc, words []string) (*AddResult, error),
+	next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error {
+	offset := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		words, err := next()
+		if err != nil || len(words) == 0 {
+			return err
+		}
+
+		total := 0
+		result, err := add(ctx, func(done, chunkTotal int) {
+			total = chunkTotal
+			progress.Report(offset+done, offset+chunkTotal)
+		}, words)
+		if err != nil {
+			return err
+		}
+
+		consume(result)
+		offset += total
+	}
+}
+
+// CheckTiles checks that none of the sets of tiles has more than MaxTiles aksharas, else ErrTooManyTiles is returned.
+func CheckTiles(tiles []string) error {
+	for _, t := range tiles {
+		if len(akshara.Split(t)) > MaxTiles {
+			return ErrTooManyTiles
+		}
+	}
+
+	return nil
+}
+
+// CompileAll compiles the regular expressions in the given order.
+// If any of the expressions is invalid then error is returned.
+func CompileAll(expressions []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(expressions))
+	for i, expression := range expressions {
+		re, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = re
+	}
+
+	return res, nil
+}
+
+// storageConstraint returns the reason why the word cannot be stored, empty if it can be.
+func storageConstraint(word string) string {
+	if len(strings.TrimSpace(word)) == 0 {
+		return "word is empty"
+	} else if !utf8.ValidString(word) {
+		return "word is not a valid UTF-8 string"
+	} else if utf8.RuneCountInString(word) > MaxWordLength {
+		return fmt.Sprintf("word is longer than %d characters", MaxWordLength)
+	}
+
+	return ""
+}
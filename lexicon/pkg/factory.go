@@ -1,44 +1,50 @@
 package lexicon
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
 
-	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/sql"
+	lexiconmem "github.com/vinaygaykar/cool-lexicon/lexicon/internal/memory"
+	lexiconsql "github.com/vinaygaykar/cool-lexicon/lexicon/internal/sql"
 	"github.com/vinaygaykar/cool-lexicon/utils"
 
 	"database/sql"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 	_ "github.com/libsql/libsql-client-go/libsql"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
 // VerifyDB verifies connection to the provided database and performs required migrations.
-// Works for MySQL & libSQL.
+// Works for MySQL, PostgreSQL, libSQL & SQLite, in memory lexicon and in memory SQLite DB have nothing to verify,
+// the schema of the latter is created by GetInstance.
 // If DB connection or migration fails then the function will panic.
 func VerifyDB(cfg *configs.Configs) {
-	dbUrl, _ := getDBUrlAndDriver(cfg)
+	if cfg.Dbtype == "memory" || isSQLiteInMemory(cfg) {
+		return
+	}
+
+	dbUrl, dbDriver := getDBUrlAndDriver(cfg)
 	var m *migrate.Migrate
 	var err error
 	if cfg.Dbtype == "mysql" {
 		m, err = migrate.New("file://db/migrations/mysql", dbUrl)
-	} else if cfg.Dbtype == "libsql" || cfg.Dbtype == "turso" {
-		if db, err := sql.Open("libsql", dbUrl); err != nil {
+	} else if cfg.Dbtype == "postgres" {
+		m, err = migrate.New("file://db/migrations/postgres", dbUrl)
+	} else if cfg.Dbtype == "libsql" || cfg.Dbtype == "turso" || cfg.Dbtype == "sqlite" {
+		if db, err := sql.Open(dbDriver, dbUrl); err != nil {
 			log.Panicf("[migrations] [%s] : could not connect to server : %s\n", cfg.Dbtype, err.Error())
 		} else {
-			if driver, err := sqlite3.WithInstance(db, &sqlite3.Config{}); err != nil {
-				log.Panicf("[migrations] [%s] : could not create driver : %s\n", cfg.Dbtype, err.Error())
-			} else {
-				if m, err = migrate.NewWithDatabaseInstance("file://db/migrations/libsql", "sqlite3", driver); err != nil {
-					log.Panicf("[migrations] [%s] : %s\n", cfg.Dbtype, err.Error())
-				}
-			}
+			m = migrateSQLite(cfg, db)
 		}
 	} else {
 		log.Panicln("invalid db type provided in the configs")
@@ -48,25 +54,73 @@ func VerifyDB(cfg *configs.Configs) {
 		log.Panicf("[migrations] [%s] : %s\n", cfg.Dbtype, err.Error())
 	} else {
 		m.Up()
+		m.Close()
+	}
+}
+
+// migrateSQLite returns the migrations of libSQL & SQLite to be performed on the DB.
+// Closing the returned migrations closes the DB as well.
+// If the migrations cannot be created then the function will panic.
+func migrateSQLite(cfg *configs.Configs, db *sql.DB) *migrate.Migrate {
+	// SQLite uses the same schema as libSQL
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		log.Panicf("[migrations] [%s] : could not create driver : %s\n", cfg.Dbtype, err.Error())
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://db/migrations/libsql", "sqlite3", driver)
+	if err != nil {
+		log.Panicf("[migrations] [%s] : %s\n", cfg.Dbtype, err.Error())
 	}
+
+	return m
 }
 
 // GetInstance returns an instance of Lexicon object configured as per the configs.
 // If configs are nil or invalid then this function will panic. 
 // If internal system connection fails then the function will panic.
-func GetInstance(cfg *configs.Configs) *lexicon.LexiconSQL {
+func GetInstance(cfg *configs.Configs) Lexicon {
 	if cfg == nil {
 		log.Panic("config is nil")
 	}
 
+	normalize, err := Normalizer(cfg.Normalization)
+	if err != nil {
+		log.Panicln(err.Error())
+	}
+
+	rules, err := Rules(cfg.Validation, cfg.MaxWordLength)
+	if err != nil {
+		log.Panicln(err.Error())
+	}
+
+	if cfg.Dbtype == "memory" {
+		log.Printf("opened in memory lexicon @ %s\n", cfg.Path)
+		return WithNormalization(WithValidation(lexiconmem.Open(cfg.Path), rules...), normalize)
+	}
+
 	dbUrl, driver := getDBUrlAndDriver(cfg)
 	db, err := sql.Open(driver, dbUrl)
 	if err != nil {
 		log.Panicln(err.Error())
 	}
 
-	log.Printf("connected to %s @ %s:%d\n", cfg.Dbtype, cfg.Host, cfg.Port)
-	return lexicon.Open(db, driver)
+	if isSQLiteInMemory(cfg) {
+		// the DB exists only as long as a connection of this handle is open, so its schema is created through it,
+		// the migrations are not closed as that would close the handle
+		if err := migrateSQLite(cfg, db).Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			log.Panicf("[migrations] [%s] : %s\n", cfg.Dbtype, err.Error())
+		}
+	}
+
+	if cfg.Dbtype == "sqlite" {
+		log.Printf("connected to %s @ %s\n", cfg.Dbtype, cfg.Path)
+	} else {
+		log.Printf("connected to %s @ %s:%d\n", cfg.Dbtype, cfg.Host, cfg.Port)
+	}
+	lxc := lexiconsql.Open(db, driver, lexiconsql.WithBatchSize(cfg.BatchSize), lexiconsql.WithAtomicAdd(cfg.AtomicAdd))
+	// words are validated in their normalized form
+	return WithNormalization(WithValidation(lxc, rules...), normalize)
 }
 
 func getDBUrlAndDriver(cfg *configs.Configs) (dbUrl, driver string) {
@@ -79,9 +133,34 @@ func getDBUrlAndDriver(cfg *configs.Configs) (dbUrl, driver string) {
 	} else if cfg.Dbtype == "mysql" {
 		driver = cfg.Dbtype
 		dbUrl = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Database)
+	} else if cfg.Dbtype == "postgres" {
+		driver = cfg.Dbtype
+		sslMode := cfg.SSLMode
+		if len(sslMode) == 0 {
+			sslMode = "disable"
+		}
+		dbUrl = (&url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.Username, cfg.Password),
+			Host:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Path:     cfg.Database,
+			RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+		}).String()
+	} else if cfg.Dbtype == "sqlite" {
+		driver = lexiconsql.SQLiteDriver // registers the `regexp` function used by regular expression searches
+		dbUrl = cfg.Path
+		if isSQLiteInMemory(cfg) {
+			// every connection gets its own private in memory DB, share it between the connections of the handle
+			dbUrl = "file::memory:?cache=shared"
+		}
 	} else {
 		log.Panicln("invalid db type provided in the configs")
 	}
 
 	return dbUrl, driver
 }
+
+// isSQLiteInMemory checks if the configs are of a SQLite DB which lives in the memory of this process.
+func isSQLiteInMemory(cfg *configs.Configs) bool {
+	return cfg.Dbtype == "sqlite" && cfg.Path == ":memory:"
+}
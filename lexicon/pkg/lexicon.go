@@ -1,33 +1,266 @@
 // Package lexicon defines an Lexicon interface.
 package lexicon
 
+import (
+	"context"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/rhyme"
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/types"
+)
+
 // A Lexicon is an collection of words.
 // Unlike dictionary, lexicon only stores words/string and no value (meaning).
 // Like dictionary, various operation such as search or add can be performed on a Lexicon.
 // A word is just a string in golang terms.
+//
+// Every operation has a variant accepting a context.Context, if the context is cancelled or its deadline
+// expires before the operation completes then the operation is abandoned and the context error is returned.
+// The context variants of the searches, except SuggestContext, take SearchOptions which narrow down and page the words,
+// the other variants keep every word. Words are returned in the Devanagari dictionary order following the varnamala,
+// e.g. कमल, काका, किरण, कंस, क्षमा, unless the SearchOptions ask for another Order.
 type Lexicon interface {
 	// Lookup checks existence of the given words.
 	// It returns array of strings of all the words that exists within the lexicon.
 	// If any error occurs then it is returned; nil or empty words will return error.
 	Lookup(words ...string) (*[]string, error)
 
+	// LookupContext is Lookup with a context.
+	LookupContext(ctx context.Context, words ...string) (*[]string, error)
+
 	// GetAllWordsStartingWith will search given 'substrings' strings and return an array of all the words that start with the string.
-	// Words are returned in lexicographical order (case insensitive).
+	// Words are returned in dictionary order.
 	// Return value is a map where key is the 'substrings' string and value is array of matching words.
 	// If any error occurs then it is returned; nil or empty words will return error.
 	GetAllWordsStartingWith(substrings ...string) (*map[string][]string, error)
 
+	// GetAllWordsStartingWithContext is GetAllWordsStartingWith with a context and the SearchOptions.
+	GetAllWordsStartingWithContext(ctx context.Context, options SearchOptions, substrings ...string) (*map[string][]string, error)
+
 	// GetAllWordsEndingWith will search given 'substrings' strings and return an array of all the words that end with the string.
-	// Words are returned in lexicographical order (case insensitive).
+	// Words are returned in dictionary order.
 	// Return value is a map where key is the 'substrings' string and value is array of matching words.
 	// If any error occurs then it is returned; nil or empty words will return error.
 	GetAllWordsEndingWith(substrings ...string) (*map[string][]string, error)
 
+	// GetAllWordsEndingWithContext is GetAllWordsEndingWith with a context and the SearchOptions.
+	GetAllWordsEndingWithContext(ctx context.Context, options SearchOptions, substrings ...string) (*map[string][]string, error)
+
+	// GetAllWordsContaining will search given 'substrings' strings and return an array of all the words that contain the string
+	// anywhere, at the start, in the middle or at the end.
+	// The memory lexicon and PostgreSQL find the words through an index of their n-grams, MySQL and SQLite scan every word
+	// of the lexicon for every substring.
+	// Words are returned in dictionary order.
+	// Return value is a map where key is the 'substrings' string and value is array of matching words.
+	// If any error occurs then it is returned; nil or empty words will return error.
+	GetAllWordsContaining(substrings ...string) (*map[string][]string, error)
+
+	// GetAllWordsContainingContext is GetAllWordsContaining with a context and the SearchOptions.
+	GetAllWordsContainingContext(ctx context.Context, options SearchOptions, substrings ...string) (*map[string][]string, error)
+
+	// GetAllWordsOfAksharaCount will search given 'prefixes' and return an array of all the words which start with the prefix
+	// and have exactly 'count' aksharas, e.g. the words of 3 aksharas starting with न. An akshara is a consonant or a conjunct
+	// along with its vowel sign, nukta, anusvara, candrabindu & visarga, or an independent vowel, so नमस्कार has 4 aksharas.
+	// An empty prefix matches every word. Words are returned in dictionary order.
+	// Return value is a map where key is the 'prefixes' string and value is array of matching words.
+	// If any error occurs then it is returned; nil or empty prefixes or count less than 1 will return error.
+	GetAllWordsOfAksharaCount(count int, prefixes ...string) (*map[string][]string, error)
+
+	// GetAllWordsOfAksharaCountContext is GetAllWordsOfAksharaCount with a context and the SearchOptions.
+	GetAllWordsOfAksharaCountContext(ctx context.Context, options SearchOptions, count int, prefixes ...string) (*map[string][]string, error)
+
+	// GetAllWordsMatching will search given wildcard 'patterns' and return an array of all the words that match the pattern.
+	// In a pattern `?` matches exactly one akshara (e.g. क, स्का or क्षि) and `*` matches any run of aksharas, including none,
+	// other characters are matched as they are, e.g. "न?स्?ार" or "न*र".
+	// Words are returned in dictionary order.
+	// Return value is a map where key is the 'patterns' string and value is array of matching words.
+	// If any error occurs then it is returned; nil or empty words will return error.
+	GetAllWordsMatching(patterns ...string) (*map[string][]string, error)
+
+	// GetAllWordsMatchingContext is GetAllWordsMatching with a context and the SearchOptions.
+	GetAllWordsMatchingContext(ctx context.Context, options SearchOptions, patterns ...string) (*map[string][]string, error)
+
+	// GetAllWordsMatchingRegexp will search given regular 'expressions' and return an array of all the words that match the
+	// expression anywhere, use `^` and `$` to match the whole word. Expressions use the RE2 syntax of the regexp package,
+	// e.g. `\p{M}` matches any vowel sign or other combining mark.
+	// Words are returned in dictionary order, at most MaxRegexpMatches words per expression.
+	// Return value is a map where key is the 'expressions' string and value is array of matching words.
+	// If an expression matches more words then the results are returned along with ErrTooManyMatches.
+	// If any other error occurs then it is returned; nil or empty words or an invalid expression will return error.
+	GetAllWordsMatchingRegexp(expressions ...string) (*map[string][]string, error)
+
+	// GetAllWordsMatchingRegexpContext is GetAllWordsMatchingRegexp with a context and the SearchOptions.
+	GetAllWordsMatchingRegexpContext(ctx context.Context, options SearchOptions, expressions ...string) (*map[string][]string, error)
+
+	// GetAllWordsSimilarTo will search given 'words' and return an array of all the words within 'maxDistance' edits of the word,
+	// where an edit is an insertion, deletion or substitution of an akshara or a swap of two adjacent aksharas,
+	// e.g. कार and कीर are one edit apart. It is useful to find a word typed with a wrong matra or a missing akshara.
+	// Words are returned in order of their distance, words at the same distance in dictionary order.
+	// A SQL lexicon reads all the words into memory on the first search, words added or removed later by other clients of
+	// the DB are not found until the lexicon is opened again. The same words are used by Suggest.
+	// Return value is a map where key is the 'words' string and value is array of similar words.
+	// If any error occurs then it is returned; nil or empty words or negative distance will return error.
+	GetAllWordsSimilarTo(maxDistance int, words ...string) (*map[string][]string, error)
+
+	// GetAllWordsSimilarToContext is GetAllWordsSimilarTo with a context and the SearchOptions.
+	GetAllWordsSimilarToContext(ctx context.Context, options SearchOptions, maxDistance int, words ...string) (*map[string][]string, error)
+
+	// GetAllWordsSoundingLike will search given 'words' and return an array of all the words which sound like the word,
+	// i.e. the words with the same phonetic key. The key does not tell apart aspirated & unaspirated consonants, the
+	// sibilants श/ष/स, long & short vowels इ/ई & उ/ऊ, nasalisation by anusvara, candrabindu or a half nasal consonant,
+	// nukta and virama, e.g. भारत sounds like बारत and नमस्ते sounds like नमसते.
+	// Return value is a map where key is the 'words' string and value is array of words in dictionary order.
+	// If any error occurs then it is returned; nil or empty words will return error.
+	GetAllWordsSoundingLike(words ...string) (*map[string][]string, error)
+
+	// GetAllWordsSoundingLikeContext is GetAllWordsSoundingLike with a context and the SearchOptions.
+	GetAllWordsSoundingLikeContext(ctx context.Context, options SearchOptions, words ...string) (*map[string][]string, error)
+
+	// GetAllWordsRhymingWith will search given 'words' and return an array of all the words which rhyme with the word,
+	// comparing the last 'syllables' aksharas as per the 'strictness', see RhymeStrictness. Unlike GetAllWordsEndingWith
+	// the vowels are compared irrespective of their written form, e.g. कार rhymes with भार and जाओ with खाको.
+	// A word does not rhyme with itself. Words are returned in dictionary order.
+	// Return value is a map where key is the 'words' string and value is array of rhyming words.
+	// If any error occurs then it is returned; nil or empty words, syllables less than 1 or unknown strictness will return error.
+	GetAllWordsRhymingWith(syllables int, strictness RhymeStrictness, words ...string) (*map[string][]string, error)
+
+	// GetAllWordsRhymingWithContext is GetAllWordsRhymingWith with a context and the SearchOptions.
+	GetAllWordsRhymingWithContext(ctx context.Context, options SearchOptions, syllables int, strictness RhymeStrictness, words ...string) (*map[string][]string, error)
+
+	// GetAllAnagramsOf will search given 'words' and return an array of all the words made of exactly the same aksharas
+	// in any order, e.g. कमल and मकल. The word itself is returned as well if it exists in the lexicon.
+	// Return value is a map where key is the 'words' string and value is array of words in dictionary order.
+	// If any error occurs then it is returned; nil or empty words will return error.
+	GetAllAnagramsOf(words ...string) (*map[string][]string, error)
+
+	// GetAllAnagramsOfContext is GetAllAnagramsOf with a context and the SearchOptions.
+	GetAllAnagramsOfContext(ctx context.Context, options SearchOptions, words ...string) (*map[string][]string, error)
+
+	// GetAllWordsFromTiles will search given 'tiles' and return an array of all the words which can be formed using some
+	// of the aksharas of the tiles, every akshara at most as many times as it occurs in the tiles, e.g. tiles कमलम form
+	// कमल and मम but not ममम.
+	// Return value is a map where key is the 'tiles' string and value is array of words in dictionary order.
+	// If any error occurs then it is returned; nil or empty tiles or tiles of more than MaxTiles aksharas will return error.
+	GetAllWordsFromTiles(tiles ...string) (*map[string][]string, error)
+
+	// GetAllWordsFromTilesContext is GetAllWordsFromTiles with a context and the SearchOptions.
+	GetAllWordsFromTilesContext(ctx context.Context, options SearchOptions, tiles ...string) (*map[string][]string, error)
+
+	// Suggest returns at most 'n' likely corrections for each of the given words which do not exist in the lexicon, the most
+	// likely first. Words differing only in commonly confused letters and signs (इ/ई, उ/ऊ and their matras, श/ष/स, anusvara,
+	// candrabindu & half nasal consonants, nukta) are the most likely, then the words at the least akshara edit distance.
+	// Return value is a map where key is an unknown word from 'words' and value is array of suggestions, possibly empty,
+	// words which exist in the lexicon have no entry.
+	// If any error occurs then it is returned; nil or empty words or 'n' less than 1 will return error.
+	Suggest(n int, words ...string) (*map[string][]string, error)
+
+	// SuggestContext is Suggest with a context.
+	SuggestContext(ctx context.Context, n int, words ...string) (*map[string][]string, error)
+
 	// Add adds the given array of words/string to current lexicon.
+	// It returns an AddResult listing the newly inserted words, the words which already existed and
+	// the rejected words (e.g. empty or too long) with the reason.
 	// If failure occurs then error is returned; nil or empty words will return error.
-	Add(words ...string) error
+	Add(words ...string) (*AddResult, error)
+
+	// AddContext is Add with a context.
+	AddContext(ctx context.Context, words ...string) (*AddResult, error)
+
+	// AddStream adds the words returned by `next` chunk by chunk until it returns no words, e.g. the words of a large
+	// file read a chunk at a time, so that all the words are never held in memory at once. `consume` is invoked with
+	// the AddResult of every chunk once it is added. `progress` is invoked after every batch of words the lexicon adds,
+	// counting the words added so far out of the words read so far, nil `progress` reports nothing.
+	// If the lexicon adds atomically, e.g. a DB configured with `atomicAdd`, then all the chunks are added in a single
+	// transaction and if failure occurs then none of the words are added, including the chunks already consumed.
+	// Otherwise the chunks consumed before a failure stay added.
+	// If failure occurs then error is returned, including the error returned by `next`.
+	AddStream(next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error
+
+	// AddStreamContext is AddStream with a context.
+	AddStreamContext(ctx context.Context, next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error
+
+	// Remove removes the given array of words/string from current lexicon.
+	// It returns the count of words which were present in the lexicon and are now removed, words
+	// which do not exist are ignored.
+	// If failure occurs then error is returned; nil or empty words will return error.
+	Remove(words ...string) (int, error)
+
+	// RemoveContext is Remove with a context.
+	RemoveContext(ctx context.Context, words ...string) (int, error)
+
+	// Rewrite replaces every word of the lexicon by the word returned by `fn`, e.g. to convert the existing
+	// words to a normalization form. Words which end up the same are stored only once.
+	// It returns the count of words which were changed.
+	// If failure occurs then error is returned and the lexicon is left unchanged; nil `fn` will return error.
+	Rewrite(fn func(word string) string) (int, error)
+
+	// RewriteContext is Rewrite with a context.
+	RewriteContext(ctx context.Context, fn func(word string) string) (int, error)
 
 	// Close will close the lexicon.
 	// Just like a book which is closed after usage.
 	Close()
 }
+
+// An AddResult reports what happened to each of the words given to Add.
+type AddResult = types.AddResult
+
+// A RejectedWord is a word which could not be added to the lexicon along with the reason.
+type RejectedWord = types.RejectedWord
+
+// MaxRegexpMatches is the maximum number of words returned for a single regular expression.
+const MaxRegexpMatches = types.MaxRegexpMatches
+
+// ErrTooManyMatches is returned along with the results when a regular expression matched more than MaxRegexpMatches words.
+var ErrTooManyMatches = types.ErrTooManyMatches
+
+// RhymeStrictness tells which parts of the trailing aksharas must be the same for words to rhyme.
+// The vowel of an akshara is its vowel sign along with anusvara, candrabindu or visarga, an independent vowel
+// is the same as its vowel sign.
+type RhymeStrictness = rhyme.Strictness
+
+const (
+	RhymeVowel = rhyme.Vowel // trailing aksharas have the same vowels, e.g. कार & पाल
+	RhymeFinal = rhyme.Final // last akshara is the same and the other trailing aksharas have the same vowels, e.g. कार & भार
+	RhymeExact = rhyme.Exact // trailing aksharas are the same, e.g. सुधार & उधार
+)
+
+// MaxTiles is the maximum number of aksharas in a set of tiles given to GetAllWordsFromTiles.
+const MaxTiles = types.MaxTiles
+
+// ErrTooManyTiles is returned when a set of tiles has more than MaxTiles aksharas.
+var ErrTooManyTiles = types.ErrTooManyTiles
+
+// ErrCursorNotFound is returned when the cursor of GetAllWordsSimilarTo is not one of the similar words, e.g. it was
+// removed since, there is no place for it among the words ranked by their distance.
+var ErrCursorNotFound = types.ErrCursorNotFound
+
+// A ProgressFunc is invoked by the bulk operations, like AddStream, after every processed batch of words,
+// `done` is the count of words processed so far out of `total` words.
+type ProgressFunc = types.ProgressFunc
+
+// SearchOptions narrow down the words returned by the searches by their count of aksharas or characters (code points),
+// or leave out some words altogether. A zero value of a limit means no limit, so the zero SearchOptions keep every word.
+// Options are applied by the storage, e.g. as part of the SQL query, before the words are returned.
+//
+// Words of every searched string can be paged either by an offset or by a cursor, e.g. to get the next page after
+// a page of `Limit` words set `After` to SearchOptions.Next of the page. Unlike offsets, cursors do not shift when
+// words are added or removed before them between the pages. Pages follow the order of the search, e.g. closest first for
+// GetAllWordsSimilarTo, and the Order of the options, see OrderDictionary. A cursor which is not one of the words is
+// placed among them by the order, except for GetAllWordsSimilarTo which returns ErrCursorNotFound.
+// Use SearchOptions.Validate to check the options given by a user.
+type SearchOptions = types.SearchOptions
+
+// Order is the order of the words returned by the searches, see SearchOptions.
+type Order = types.Order
+
+const (
+	// OrderDictionary orders the words as a Marathi or Hindi dictionary does, following the varnamala, so a consonant
+	// is followed by its barakhadi क का कि ... कौ कं कः and then by its conjuncts. It is the default order.
+	OrderDictionary = types.OrderDictionary
+
+	// OrderCodepoint orders the words by their code points, e.g. कं comes before का as anusvara precedes the vowel signs.
+	OrderCodepoint = types.OrderCodepoint
+
+	// OrderStorage returns the words as ordered by the storage, e.g. by the collation of a database.
+	OrderStorage = types.OrderStorage
+)
@@ -0,0 +1,265 @@
+package lexicon
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization forms which can be configured for a lexicon.
+// The same word can be typed in different byte sequences, e.g. precomposed क़ (U+0958) and क followed by
+// nukta, normalizing every word to a single form makes them compare equal.
+const (
+	NormalizationNFC  = "nfc"  // canonical composition, the default
+	NormalizationNFKC = "nfkc" // compatibility composition, also folds compatibility characters
+	NormalizationNone = "none" // words are used as given
+)
+
+// Normalizer returns the function which converts a word to the given normalization form.
+// Empty form is the default NFC. It returns nil function for NormalizationNone and error for an unknown form.
+func Normalizer(form string) (func(word string) string, error) {
+	if len(form) == 0 || form == NormalizationNFC {
+		return norm.NFC.String, nil
+	} else if form == NormalizationNFKC {
+		return norm.NFKC.String, nil
+	} else if form == NormalizationNone {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unknown normalization form: %s", form)
+}
+
+// WithNormalization returns a Lexicon which normalizes, using `normalize`, the words given to every operation
+// before passing them to `lxc`. Words returned by Lookup and keys of the search results are the words as given
+// by the caller, so callers can match them with their input.
+func WithNormalization(lxc Lexicon, normalize func(word string) string) Lexicon {
+	if normalize == nil {
+		return lxc
+	}
+
+	return &normalizingLexicon{Lexicon: lxc, normalize: normalize}
+}
+
+// normalizingLexicon is a Lexicon which normalizes the words before handing them to the wrapped Lexicon.
+type normalizingLexicon struct {
+	Lexicon
+	normalize func(word string) string
+}
+
+func (lxc *normalizingLexicon) Lookup(words ...string) (*[]string, error) {
+	return lxc.LookupContext(context.Background(), words...)
+}
+
+func (lxc *normalizingLexicon) LookupContext(ctx context.Context, words ...string) (*[]string, error) {
+	found, err := lxc.Lexicon.LookupContext(ctx, lxc.normalizeAll(words)...)
+	if found == nil || err != nil {
+		return found, err
+	}
+
+	// report the words as given, more than one given word may have the same normalized form
+	exists := make(map[string]struct{}, len(*found))
+	for _, word := range *found {
+		exists[word] = struct{}{}
+	}
+
+	given := make([]string, 0, len(*found))
+	for _, word := range words {
+		if _, ok := exists[lxc.normalize(word)]; ok {
+			given = append(given, word)
+		}
+	}
+
+	return &given, nil
+}
+
+func (lxc *normalizingLexicon) GetAllWordsStartingWith(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsStartingWithContext(context.Background(), SearchOptions{}, substrings...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsStartingWithContext(ctx context.Context, options SearchOptions, substrings ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsStartingWithContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(substrings)...)
+	return lxc.rekey(substrings, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsEndingWith(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsEndingWithContext(context.Background(), SearchOptions{}, substrings...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsEndingWithContext(ctx context.Context, options SearchOptions, substrings ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsEndingWithContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(substrings)...)
+	return lxc.rekey(substrings, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsContaining(substrings ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsContainingContext(context.Background(), SearchOptions{}, substrings...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsContainingContext(ctx context.Context, options SearchOptions, substrings ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsContainingContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(substrings)...)
+	return lxc.rekey(substrings, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsOfAksharaCount(count int, prefixes ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsOfAksharaCountContext(context.Background(), SearchOptions{}, count, prefixes...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsOfAksharaCountContext(ctx context.Context, options SearchOptions, count int, prefixes ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsOfAksharaCountContext(ctx, lxc.normalizeOptions(options), count, lxc.normalizeAll(prefixes)...)
+	return lxc.rekey(prefixes, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsMatching(patterns ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsMatchingContext(context.Background(), SearchOptions{}, patterns...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsMatchingContext(ctx context.Context, options SearchOptions, patterns ...string) (*map[string][]string, error) {
+	// wildcards are not affected by normalization
+	result, err := lxc.Lexicon.GetAllWordsMatchingContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(patterns)...)
+	return lxc.rekey(patterns, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsMatchingRegexp(expressions ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsMatchingRegexpContext(context.Background(), SearchOptions{}, expressions...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsMatchingRegexpContext(ctx context.Context, options SearchOptions, expressions ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsMatchingRegexpContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(expressions)...)
+	return lxc.rekey(expressions, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsSimilarTo(maxDistance int, words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsSimilarToContext(context.Background(), SearchOptions{}, maxDistance, words...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsSimilarToContext(ctx context.Context, options SearchOptions, maxDistance int, words ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsSimilarToContext(ctx, lxc.normalizeOptions(options), maxDistance, lxc.normalizeAll(words)...)
+	return lxc.rekey(words, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsSoundingLike(words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsSoundingLikeContext(context.Background(), SearchOptions{}, words...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsSoundingLikeContext(ctx context.Context, options SearchOptions, words ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsSoundingLikeContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(words)...)
+	return lxc.rekey(words, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsRhymingWith(syllables int, strictness RhymeStrictness, words ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsRhymingWithContext(context.Background(), SearchOptions{}, syllables, strictness, words...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsRhymingWithContext(ctx context.Context, options SearchOptions, syllables int, strictness RhymeStrictness, words ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsRhymingWithContext(ctx, lxc.normalizeOptions(options), syllables, strictness, lxc.normalizeAll(words)...)
+	return lxc.rekey(words, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllAnagramsOf(words ...string) (*map[string][]string, error) {
+	return lxc.GetAllAnagramsOfContext(context.Background(), SearchOptions{}, words...)
+}
+
+func (lxc *normalizingLexicon) GetAllAnagramsOfContext(ctx context.Context, options SearchOptions, words ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllAnagramsOfContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(words)...)
+	return lxc.rekey(words, result), err
+}
+
+func (lxc *normalizingLexicon) GetAllWordsFromTiles(tiles ...string) (*map[string][]string, error) {
+	return lxc.GetAllWordsFromTilesContext(context.Background(), SearchOptions{}, tiles...)
+}
+
+func (lxc *normalizingLexicon) GetAllWordsFromTilesContext(ctx context.Context, options SearchOptions, tiles ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.GetAllWordsFromTilesContext(ctx, lxc.normalizeOptions(options), lxc.normalizeAll(tiles)...)
+	return lxc.rekey(tiles, result), err
+}
+
+func (lxc *normalizingLexicon) Suggest(n int, words ...string) (*map[string][]string, error) {
+	return lxc.SuggestContext(context.Background(), n, words...)
+}
+
+func (lxc *normalizingLexicon) SuggestContext(ctx context.Context, n int, words ...string) (*map[string][]string, error) {
+	result, err := lxc.Lexicon.SuggestContext(ctx, n, lxc.normalizeAll(words)...)
+	return lxc.rekey(words, result), err
+}
+
+func (lxc *normalizingLexicon) Add(words ...string) (*AddResult, error) {
+	return lxc.AddContext(context.Background(), words...)
+}
+
+func (lxc *normalizingLexicon) AddContext(ctx context.Context, words ...string) (*AddResult, error) {
+	return lxc.Lexicon.AddContext(ctx, lxc.normalizeAll(words)...)
+}
+
+func (lxc *normalizingLexicon) AddStream(next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error {
+	return lxc.AddStreamContext(context.Background(), next, consume, progress)
+}
+
+func (lxc *normalizingLexicon) AddStreamContext(ctx context.Context, next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error {
+	return lxc.Lexicon.AddStreamContext(ctx, func() ([]string, error) {
+		words, err := next()
+		return lxc.normalizeAll(words), err
+	}, consume, progress)
+}
+
+func (lxc *normalizingLexicon) Remove(words ...string) (int, error) {
+	return lxc.RemoveContext(context.Background(), words...)
+}
+
+func (lxc *normalizingLexicon) RemoveContext(ctx context.Context, words ...string) (int, error) {
+	return lxc.Lexicon.RemoveContext(ctx, lxc.normalizeAll(words)...)
+}
+
+func (lxc *normalizingLexicon) Rewrite(fn func(word string) string) (int, error) {
+	return lxc.RewriteContext(context.Background(), fn)
+}
+
+func (lxc *normalizingLexicon) RewriteContext(ctx context.Context, fn func(word string) string) (int, error) {
+	if fn == nil {
+		return lxc.Lexicon.RewriteContext(ctx, fn)
+	}
+
+	// rewritten words are stored normalized as well
+	return lxc.Lexicon.RewriteContext(ctx, func(word string) string {
+		return lxc.normalize(fn(word))
+	})
+}
+
+// normalizeAll returns the normalized copy of the words, nil words stay nil.
+func (lxc *normalizingLexicon) normalizeAll(words []string) []string {
+	if words == nil {
+		return nil
+	}
+
+	normalized := make([]string, len(words))
+	for i, word := range words {
+		normalized[i] = lxc.normalize(word)
+	}
+
+	return normalized
+}
+
+// normalizeOptions returns the search options with the excluded words and the cursor normalized.
+func (lxc *normalizingLexicon) normalizeOptions(options SearchOptions) SearchOptions {
+	options.Exclude = lxc.normalizeAll(options.Exclude)
+	if len(options.After) != 0 {
+		options.After = lxc.normalize(options.After)
+	}
+
+	return options
+}
+
+// rekey returns the search result keyed by the substrings as given instead of their normalized form.
+func (lxc *normalizingLexicon) rekey(substrings []string, result *map[string][]string) *map[string][]string {
+	if result == nil {
+		return nil
+	}
+
+	rekeyed := make(map[string][]string, len(*result))
+	for _, substring := range substrings {
+		if words, ok := (*result)[lxc.normalize(substring)]; ok {
+			rekeyed[substring] = words
+		}
+	}
+
+	return &rekeyed
+}
@@ -0,0 +1,125 @@
+package lexicon
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	lexiconmem "github.com/vinaygaykar/cool-lexicon/lexicon/internal/memory"
+)
+
+// precomposed क़ (U+0958) is decomposed to क + nukta by NFC as it is excluded from composition
+const (
+	precomposedQa = "\u0958"
+	decomposedQa  = "\u0915\u093c"
+)
+
+func TestNormalizer(t *testing.T) {
+	tests := []struct {
+		name    string
+		form    string
+		word    string
+		want    string
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "Given empty form, when Normalizer is invoked, then NFC is used",
+			form: "",
+			word: precomposedQa + "लम",
+			want: decomposedQa + "लम",
+		},
+		{
+			name: "Given nfkc form, when Normalizer is invoked, then compatibility characters are folded",
+			form: NormalizationNFKC,
+			word: "ﬁ",
+			want: "fi",
+		},
+		{
+			name:    "Given none form, when Normalizer is invoked, then no function is returned",
+			form:    NormalizationNone,
+			wantNil: true,
+		},
+		{
+			name:    "Given unknown form, when Normalizer is invoked, then error is expected",
+			form:    "nfx",
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalizer(tt.form)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Normalizer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("Normalizer() = %v, wantNil %v", got != nil, tt.wantNil)
+				return
+			}
+			if got != nil && got(tt.word) != tt.want {
+				t.Errorf("Normalizer()(%q) = %q, want %q", tt.word, got(tt.word), tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNormalization(t *testing.T) {
+	normalize, _ := Normalizer(NormalizationNFC)
+	lxc := WithNormalization(lexiconmem.Open(""), normalize)
+	defer lxc.Close()
+
+	// both encodings of the same word are stored once
+	added, err := lxc.Add(precomposedQa+"लम", decomposedQa+"लम")
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if want := []string{decomposedQa + "लम"}; !reflect.DeepEqual(added.Inserted, want) {
+		t.Errorf("Add() inserted = %q, want %q", added.Inserted, want)
+	}
+
+	// the words are reported as given
+	found, _ := lxc.Lookup(precomposedQa+"लम", decomposedQa+"लम")
+	if want := &([]string{precomposedQa + "लम", decomposedQa + "लम"}); !reflect.DeepEqual(found, want) {
+		t.Errorf("Lookup() = %q, want %q", *found, *want)
+	}
+
+	starts, _ := lxc.GetAllWordsStartingWith(precomposedQa)
+	if want := &(map[string][]string{precomposedQa: {decomposedQa + "लम"}}); !reflect.DeepEqual(starts, want) {
+		t.Errorf("GetAllWordsStartingWith() = %q, want %q", *starts, *want)
+	}
+
+	// excluded words are normalized as well
+	options := SearchOptions{Exclude: []string{precomposedQa + "लम"}}
+	starts, _ = lxc.GetAllWordsStartingWithContext(context.Background(), options, precomposedQa)
+	if want := &map[string][]string{}; !reflect.DeepEqual(starts, want) {
+		t.Errorf("GetAllWordsStartingWithContext() = %q, want %q", *starts, *want)
+	}
+
+	removed, _ := lxc.Remove(precomposedQa + "लम")
+	if removed != 1 {
+		t.Errorf("Remove() = %v, want %v", removed, 1)
+	}
+}
+
+func TestWithNormalization_Cursor(t *testing.T) {
+	normalize, _ := Normalizer(NormalizationNFC)
+	lxc := WithNormalization(lexiconmem.Open(""), normalize)
+	defer lxc.Close()
+
+	// ऩ (U+0929) is stored composed, the cursor is given decomposed as न + nukta
+	lxc.Add("\u0929ा", "\u0929ी", "\u0929ू")
+	options := SearchOptions{Limit: 1, After: "\u0928\u093cा"}
+
+	starts, _ := lxc.GetAllWordsStartingWithContext(context.Background(), options, "\u0929")
+	if want := &(map[string][]string{"\u0929": {"\u0929ी"}}); !reflect.DeepEqual(starts, want) {
+		t.Errorf("GetAllWordsStartingWithContext() = %q, want %q", *starts, *want)
+	}
+
+	similar, err := lxc.GetAllWordsSimilarToContext(context.Background(), options, 1, "\u0929ा")
+	if want := &(map[string][]string{"\u0929ा": {"\u0929ी"}}); err != nil || !reflect.DeepEqual(similar, want) {
+		t.Errorf("GetAllWordsSimilarToContext() = %v, %v, want %q", similar, err, *want)
+	}
+}
@@ -0,0 +1,204 @@
+package lexicon
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/internal/types"
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+// Validation rules which can be configured for a lexicon, words breaking any of the rules are rejected by Add.
+const (
+	ValidationDevanagari  = "devanagari"  // only letters and signs of the Devanagari script, joiners only within the word
+	ValidationLeadingMark = "leadingMark" // word does not start with a combining mark such as a vowel sign or virama
+	ValidationMaxLength   = "maxLength"   // word is not longer than the configured maximum length
+	ValidationWhitespace  = "whitespace"  // word has no whitespace
+)
+
+const (
+	// MaxWordLength is the maximum number of characters (code points) the lexicon can store in a word.
+	MaxWordLength = types.MaxWordLength
+)
+
+// A Rule checks a word before it is added to the lexicon.
+// It returns the reason why the word is rejected, empty if the word is valid.
+type Rule func(word string) string
+
+// Rules returns the rules with the given names, `maxLength` is the limit used by the ValidationMaxLength rule,
+// zero means MaxWordLength. Nil or empty names are no rules at all, i.e. validation is opt-in.
+// It returns error for an unknown rule name or a `maxLength` which cannot be stored.
+func Rules(names []string, maxLength int) ([]Rule, error) {
+	if maxLength == 0 {
+		maxLength = MaxWordLength
+	} else if maxLength < 0 || maxLength > MaxWordLength {
+		return nil, fmt.Errorf("max word length must be between 1 and %d: %d", MaxWordLength, maxLength)
+	}
+
+	rules := make([]Rule, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case ValidationDevanagari:
+			rules = append(rules, DevanagariOnly)
+		case ValidationLeadingMark:
+			rules = append(rules, NoLeadingMark)
+		case ValidationMaxLength:
+			rules = append(rules, MaxLength(maxLength))
+		case ValidationWhitespace:
+			rules = append(rules, NoWhitespace)
+		default:
+			return nil, fmt.Errorf("unknown validation rule: %s", name)
+		}
+	}
+
+	return rules, nil
+}
+
+// DevanagariOnly is a Rule which rejects words having anything other than Devanagari letters and signs,
+// e.g. Latin letters, digits, punctuation or emoji. Zero width joiner & non-joiner are allowed within the word.
+func DevanagariOnly(word string) string {
+	runes := []rune(word)
+	for i, r := range runes {
+		if akshara.IsWordRune(r) {
+			continue
+		} else if (r == akshara.ZWNJ || r == akshara.ZWJ) && i != 0 && i != len(runes)-1 {
+			continue
+		}
+
+		return fmt.Sprintf("word has non Devanagari character %q", r)
+	}
+
+	return ""
+}
+
+// NoLeadingMark is a Rule which rejects words starting with a combining mark, such as a vowel sign, virama
+// or nukta, which has no letter to combine with.
+func NoLeadingMark(word string) string {
+	if r, _ := utf8.DecodeRuneInString(word); unicode.Is(unicode.M, r) {
+		return fmt.Sprintf("word starts with combining mark %q", r)
+	}
+
+	return ""
+}
+
+// MaxLength returns a Rule which rejects words longer than `length` characters (code points).
+func MaxLength(length int) Rule {
+	return func(word string) string {
+		if utf8.RuneCountInString(word) > length {
+			return fmt.Sprintf("word is longer than %d characters", length)
+		}
+
+		return ""
+	}
+}
+
+// NoWhitespace is a Rule which rejects words having whitespace, including leading and trailing whitespace.
+func NoWhitespace(word string) string {
+	for _, r := range word {
+		if unicode.IsSpace(r) {
+			return "word has whitespace"
+		}
+	}
+
+	return ""
+}
+
+// WithValidation returns a Lexicon which checks the words given to Add against the rules before passing the
+// valid words to `lxc`. Words breaking a rule are reported as rejected with the reason of the first broken rule.
+func WithValidation(lxc Lexicon, rules ...Rule) Lexicon {
+	if len(rules) == 0 {
+		return lxc
+	}
+
+	return &validatingLexicon{Lexicon: lxc, rules: rules}
+}
+
+// validatingLexicon is a Lexicon which rejects the words breaking any of the rules before they are added to
+// the wrapped Lexicon.
+type validatingLexicon struct {
+	Lexicon
+	rules []Rule
+}
+
+func (lxc *validatingLexicon) Add(words ...string) (*AddResult, error) {
+	return lxc.AddContext(context.Background(), words...)
+}
+
+func (lxc *validatingLexicon) AddContext(ctx context.Context, words ...string) (*AddResult, error) {
+	if len(words) == 0 {
+		return lxc.Lexicon.AddContext(ctx, words...)
+	}
+
+	valid, rejected := lxc.check(words)
+	if len(valid) == 0 {
+		// nothing left to add, the wrapped lexicon would report empty words as an error
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return &AddResult{Inserted: make([]string, 0), Existing: make([]string, 0), Rejected: rejected}, nil
+	}
+
+	result, err := lxc.Lexicon.AddContext(ctx, valid...)
+	if result != nil {
+		result.Rejected = append(rejected, result.Rejected...)
+	}
+
+	return result, err
+}
+
+func (lxc *validatingLexicon) AddStream(next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error {
+	return lxc.AddStreamContext(context.Background(), next, consume, progress)
+}
+
+func (lxc *validatingLexicon) AddStreamContext(ctx context.Context, next func() ([]string, error), consume func(result *AddResult), progress ProgressFunc) error {
+	// rejected words of a chunk are reported along with the result of its valid words
+	var rejected []RejectedWord
+	return lxc.Lexicon.AddStreamContext(ctx, func() ([]string, error) {
+		for {
+			words, err := next()
+			if err != nil || len(words) == 0 {
+				return nil, err
+			}
+
+			var valid []string
+			if valid, rejected = lxc.check(words); len(valid) != 0 {
+				return valid, nil
+			}
+
+			// nothing left to add in this chunk, the wrapped lexicon would take no words as the end
+			consume(&AddResult{Inserted: make([]string, 0), Existing: make([]string, 0), Rejected: rejected})
+		}
+	}, func(result *AddResult) {
+		result.Rejected = append(rejected, result.Rejected...)
+		consume(result)
+	}, progress)
+}
+
+// check splits the words into the valid words and the rejected words.
+func (lxc *validatingLexicon) check(words []string) (valid []string, rejected []RejectedWord) {
+	valid = make([]string, 0, len(words))
+	rejected = make([]RejectedWord, 0)
+	for _, word := range words {
+		if reason := lxc.validate(word); len(reason) != 0 {
+			rejected = append(rejected, RejectedWord{Word: word, Reason: reason})
+		} else {
+			valid = append(valid, word)
+		}
+	}
+
+	return valid, rejected
+}
+
+// validate returns the reason of the first rule the word breaks, empty if the word is valid.
+func (lxc *validatingLexicon) validate(word string) string {
+	for _, rule := range lxc.rules {
+		if reason := rule(word); len(reason) != 0 {
+			return reason
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,176 @@
+package lexicon
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	lexiconmem "github.com/vinaygaykar/cool-lexicon/lexicon/internal/memory"
+)
+
+// builtInRules are the names of all the built-in rules.
+var builtInRules = []string{ValidationWhitespace, ValidationMaxLength, ValidationLeadingMark, ValidationDevanagari}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		names     []string
+		maxLength int
+		word      string
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "Given all names, when Rules is invoked, then a Devanagari word is valid",
+			names:     builtInRules,
+			word:      "नमस्कार",
+			wantValid: true,
+		},
+		{
+			name:  "Given all names, when Rules is invoked, then an English word is rejected",
+			names: builtInRules,
+			word:  "hello",
+		},
+		{
+			name:  "Given all names, when Rules is invoked, then an emoji is rejected",
+			names: builtInRules,
+			word:  "नमस्ते🙏",
+		},
+		{
+			name:  "Given all names, when Rules is invoked, then a word starting with a vowel sign is rejected",
+			names: builtInRules,
+			word:  "ाम",
+		},
+		{
+			name:  "Given all names, when Rules is invoked, then a word with whitespace is rejected",
+			names: builtInRules,
+			word:  "नमस् ते",
+		},
+		{
+			name:      "Given all names, when Rules is invoked, then a joiner within the word is valid",
+			names:     builtInRules,
+			word:      "क्‍ष",
+			wantValid: true,
+		},
+		{
+			name:  "Given all names, when Rules is invoked, then a trailing joiner is rejected",
+			names: builtInRules,
+			word:  "क्‍",
+		},
+		{
+			name:      "Given max length, when Rules is invoked, then a longer word is rejected",
+			names:     []string{ValidationMaxLength},
+			maxLength: 3,
+			word:      "नमस्कार",
+		},
+		{
+			name:      "Given nil names, when Rules is invoked, then every word is valid",
+			word:      "hello world",
+			wantValid: true,
+		},
+		{
+			name:      "Given empty names, when Rules is invoked, then every word is valid",
+			names:     []string{},
+			word:      "hello world",
+			wantValid: true,
+		},
+		{
+			name:      "Given only the whitespace rule, when Rules is invoked, then an English word is valid",
+			names:     []string{ValidationWhitespace},
+			word:      "hello",
+			wantValid: true,
+		},
+		{
+			name:    "Given unknown name, when Rules is invoked, then error is expected",
+			names:   []string{"latin"},
+			wantErr: true,
+		},
+		{
+			name:      "Given max length beyond the storage limit, when Rules is invoked, then error is expected",
+			maxLength: MaxWordLength + 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := Rules(tt.names, tt.maxLength)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rules() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			reasons := make([]string, 0)
+			for _, rule := range rules {
+				if reason := rule(tt.word); len(reason) != 0 {
+					reasons = append(reasons, reason)
+				}
+			}
+			if !tt.wantErr && (len(reasons) == 0) != tt.wantValid {
+				t.Errorf("Rules()(%q) = %v, wantValid %v", tt.word, reasons, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestWithValidation(t *testing.T) {
+	rules, _ := Rules(builtInRules, 0)
+	lxc := WithValidation(lexiconmem.Open(""), rules...)
+	defer lxc.Close()
+
+	added, err := lxc.Add("नमस्ते", "hello", "ि", strings.Repeat("क", MaxWordLength+1))
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if want := []string{"नमस्ते"}; !reflect.DeepEqual(added.Inserted, want) {
+		t.Errorf("Add() inserted = %q, want %q", added.Inserted, want)
+	}
+	if len(added.Rejected) != 3 {
+		t.Errorf("Add() rejected = %v, want 3 words", added.Rejected)
+	}
+
+	// only invalid words are not an error
+	added, err = lxc.Add("hello")
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(added.Inserted) != 0 || len(added.Rejected) != 1 {
+		t.Errorf("Add() = %v, want only rejected word", added)
+	}
+
+	if _, err = lxc.Add(); err == nil {
+		t.Errorf("Add() error = nil, want error for empty words")
+	}
+}
+
+func TestWithValidation_AddStream(t *testing.T) {
+	rules, _ := Rules(builtInRules, 0)
+	lxc := WithValidation(lexiconmem.Open(""), rules...)
+	defer lxc.Close()
+
+	chunks := [][]string{{"नमस्ते", "hello"}, {"hello"}, {"पानी"}}
+	next := func() ([]string, error) {
+		if len(chunks) == 0 {
+			return nil, nil
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}
+
+	inserted, rejected := make([]string, 0), 0
+	err := lxc.AddStream(next, func(result *AddResult) {
+		inserted = append(inserted, result.Inserted...)
+		rejected += len(result.Rejected)
+	}, nil)
+	if err != nil {
+		t.Fatalf("AddStream() error = %v", err)
+	}
+	if want := []string{"नमस्ते", "पानी"}; !reflect.DeepEqual(inserted, want) {
+		t.Errorf("AddStream() inserted = %q, want %q", inserted, want)
+	}
+	// a chunk of only invalid words does not end the stream
+	if rejected != 2 {
+		t.Errorf("AddStream() rejected = %v, want 2 words", rejected)
+	}
+}
@@ -0,0 +1,150 @@
+// Package akshara splits Devanagari text into aksharas, the written syllables of the script.
+//
+// An akshara is a consonant, or a conjunct of consonants joined by virama, along with its nukta, vowel sign,
+// and signs like anusvara, candrabindu & visarga, e.g. "नमस्कार" has the aksharas न, म, स्का & र.
+// An independent vowel with its signs is an akshara as well. A virama followed by zero width non-joiner ends
+// the akshara, the next consonant starts a new one. Any other character is an akshara on its own.
+//
+// Text is split at once by Split, counted by Count and read akshara by akshara from a stream through
+// a bufio.Scanner using ScanAksharas. IsConsonant, IsNasal & IsWordRune classify the
+// characters of the script and FoldNasals writes the half nasal consonants as anusvara.
+package akshara
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Signs of the script which are part of an akshara, along with the joiners which shape a conjunct.
+const (
+	Virama      = '\u094D'
+	Nukta       = '\u093C'
+	Anusvara    = '\u0902'
+	Candrabindu = '\u0901'
+	Visarga     = '\u0903'
+	ZWNJ        = '\u200C' // zero width non-joiner
+	ZWJ         = '\u200D' // zero width joiner
+)
+
+// Split returns the aksharas of the text in order.
+func Split(text string) []string {
+	runes := []rune(text)
+	aksharas := make([]string, 0, len(runes))
+	for start := 0; start < len(runes); {
+		end := next(runes, start)
+		aksharas = append(aksharas, string(runes[start:end]))
+		start = end
+	}
+
+	return aksharas
+}
+
+// Count returns the count of aksharas in the text, it is the length of the text as read by a person.
+func Count(text string) int {
+	runes := []rune(text)
+	count := 0
+	for start := 0; start < len(runes); start = next(runes, start) {
+		count++
+	}
+
+	return count
+}
+
+// ScanAksharas is a split function for a bufio.Scanner which returns every akshara of the text as a token,
+// see Split. An akshara is returned only once the rune after it is read, as a mark may still follow it.
+func ScanAksharas(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	runes := make([]rune, 0, 8)
+	ends := make([]int, 0, 8) // byte offset just past every decoded rune
+	for width := 0; width < len(data); {
+		if !atEOF && !utf8.FullRune(data[width:]) {
+			break
+		}
+
+		r, size := utf8.DecodeRune(data[width:])
+		runes, width = append(runes, r), width+size
+		ends = append(ends, width)
+
+		if end := next(runes, 0); end < len(runes) {
+			return ends[end-1], data[:ends[end-1]], nil
+		}
+	}
+
+	if atEOF && len(data) != 0 {
+		return len(data), data, nil
+	}
+
+	// request more data
+	return 0, nil, nil
+}
+
+// next returns the index just past the akshara starting at `start`.
+func next(runes []rune, start int) int {
+	conjunct := IsConsonant(runes[start])
+	i := start + 1
+	for i < len(runes) {
+		r := runes[i]
+		if r == Virama && conjunct {
+			i++
+			if i < len(runes) && runes[i] == ZWJ {
+				i++ // joiner requests the half form, the conjunct continues
+			}
+			if i < len(runes) && IsConsonant(runes[i]) {
+				i++
+			}
+		} else if unicode.Is(unicode.M, r) || r == ZWJ || r == ZWNJ {
+			i++
+		} else {
+			break
+		}
+	}
+
+	return i
+}
+
+// IsConsonant checks if the rune is a Devanagari consonant, including the nukta forms and additional consonants.
+func IsConsonant(r rune) bool {
+	return (r >= '\u0915' && r <= '\u0939') || (r >= '\u0958' && r <= '\u095F') || (r >= '\u0978' && r <= '\u097F')
+}
+
+// IsNasal checks if the rune is one of the nasal consonants ङ, ञ, ण, न & म.
+func IsNasal(r rune) bool {
+	return r == 'ङ' || r == 'ञ' || r == 'ण' || r == 'न' || r == 'म'
+}
+
+// FoldNasals returns the text with every half nasal consonant, i.e. a nasal consonant with virama before another
+// consonant, written as anusvara, e.g. हिन्दी as हिंदी. Both are spellings of the same sound.
+func FoldNasals(text string) string {
+	runes := []rune(text)
+	folded := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		if IsNasal(runes[i]) && i+2 < len(runes) && runes[i+1] == Virama && IsConsonant(runes[i+2]) {
+			folded = append(folded, Anusvara)
+			i++
+			continue
+		}
+		folded = append(folded, runes[i])
+	}
+
+	return string(folded)
+}
+
+// IsWordRune checks if the rune can be part of a Devanagari word.
+// Danda, double danda, abbreviation sign and digits of the Devanagari block are not word runes.
+func IsWordRune(r rune) bool {
+	switch {
+	case r >= 'ऀ' && r <= 'ॣ': // signs, letters, vowel signs, virama, nukta letters
+		return true
+	case r >= '।' && r <= '॰': // danda, double danda, digits & abbreviation sign
+		return false
+	case r >= 'ॱ' && r <= 'ॿ': // high spacing dot & additional letters
+		return true
+	case r >= '꣠' && r <= 'ꣷ': // Devanagari Extended: combining digits, letters & signs
+		return true
+	case r == 'ꣻ' || (r >= 'ꣽ' && r <= 'ꣿ'): // Devanagari Extended: headstroke, om & letters
+		return true
+	case r >= '᳐' && r <= '᳿': // Vedic Extensions
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,240 @@
+package akshara
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "Given a word with a conjunct, when Split is invoked, then the conjunct and its vowel sign are one akshara",
+			text: "नमस्कार",
+			want: []string{"न", "म", "स्का", "र"},
+		},
+		{
+			name: "Given a word with a conjunct of three consonants, when Split is invoked, then all of them are one akshara",
+			text: "राष्ट्र",
+			want: []string{"रा", "ष्ट्र"},
+		},
+		{
+			name: "Given a word with nukta, anusvara and visarga, when Split is invoked, then the signs stay with their consonant",
+			text: "ज़िंदगी दुःख",
+			want: []string{"ज़िं", "द", "गी", " ", "दुः", "ख"},
+		},
+		{
+			name: "Given a word with an independent vowel and candrabindu, when Split is invoked, then the vowel is an akshara",
+			text: "आँख",
+			want: []string{"आँ", "ख"},
+		},
+		{
+			name: "Given a word ending with virama, when Split is invoked, then the dead consonant is an akshara",
+			text: "अहम्",
+			want: []string{"अ", "ह", "म्"},
+		},
+		{
+			name: "Given joiners after virama, when Split is invoked, then joiner keeps the conjunct while non-joiner breaks it",
+			text: "क्\u200Dष र्\u200Cय",
+			want: []string{"क्\u200Dष", " ", "र्\u200C", "य"},
+		},
+		{
+			name: "Given eyelash ra written with joiner, when Split is invoked, then the half ra joins the next consonant",
+			text: "र्\u200Dया",
+			want: []string{"र्\u200Dया"},
+		},
+		{
+			name: "Given a conjunct with candrabindu, when Split is invoked, then the sign stays with the conjunct",
+			text: "स्वँ",
+			want: []string{"स्वँ"},
+		},
+		{
+			name: "Given a word with precomposed nukta letters, when Split is invoked, then every letter is an akshara",
+			text: "ज़मीन",
+			want: []string{"ज़", "मी", "न"},
+		},
+		{
+			name: "Given a leading vowel sign, when Split is invoked, then the sign is an akshara on its own",
+			text: "ाम",
+			want: []string{"ा", "म"},
+		},
+		{
+			name: "Given avagraha, om, danda and digits, when Split is invoked, then every character is an akshara",
+			text: "ऽॐ।२३",
+			want: []string{"ऽ", "ॐ", "।", "२", "३"},
+		},
+		{
+			name: "Given Latin text, when Split is invoked, then every character is an akshara",
+			text: "ab",
+			want: []string{"a", "b"},
+		},
+		{
+			name: "Given empty text, when Split is invoked, then there are no aksharas",
+			text: "",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{
+			name: "Given a word with a conjunct, when Count is invoked, then the conjunct is counted once",
+			text: "नमस्कार",
+			want: 4,
+		},
+		{
+			name: "Given a word with vowel signs and anusvara, when Count is invoked, then signs are not counted",
+			text: "सुंदर",
+			want: 3,
+		},
+		{
+			name: "Given a word ending with virama, when Count is invoked, then the dead consonant is counted",
+			text: "अहम्",
+			want: 3,
+		},
+		{
+			name: "Given a conjunct broken by non-joiner, when Count is invoked, then both consonants are counted",
+			text: "र्\u200Cय",
+			want: 2,
+		},
+		{
+			name: "Given empty text, when Count is invoked, then zero is expected",
+			text: "",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Count(tt.text); got != tt.want {
+				t.Errorf("Count(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanAksharas(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{
+			name: "Given words with conjuncts, when ScanAksharas reads them a byte at a time, then the aksharas of Split are expected",
+			text: "नमस्कार राष्ट्र",
+		},
+		{
+			name: "Given a conjunct with joiners at the end of the text, when ScanAksharas reads it a byte at a time, then the aksharas of Split are expected",
+			text: "आँख क्\u200Dष र्\u200C",
+		},
+		{
+			name: "Given text ending with an incomplete rune, when ScanAksharas reads it, then the bytes are an akshara",
+			text: "कà¤",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(tt.text)))
+			scanner.Split(ScanAksharas)
+
+			got := make([]string, 0)
+			for scanner.Scan() {
+				got = append(got, scanner.Text())
+			}
+
+			if want := Split(tt.text); !reflect.DeepEqual(got, want) {
+				t.Errorf("ScanAksharas() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestIsConsonant(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want bool
+	}{
+		{name: "Given the first consonant, when IsConsonant is invoked, then true is expected", r: 'क', want: true},
+		{name: "Given the last consonant, when IsConsonant is invoked, then true is expected", r: 'ह', want: true},
+		{name: "Given a precomposed nukta letter, when IsConsonant is invoked, then true is expected", r: '\u095C', want: true},
+		{name: "Given an additional consonant, when IsConsonant is invoked, then true is expected", r: '\u097B', want: true},
+		{name: "Given an independent vowel, when IsConsonant is invoked, then false is expected", r: 'अ', want: false},
+		{name: "Given a vowel sign, when IsConsonant is invoked, then false is expected", r: 'ा', want: false},
+		{name: "Given a Latin letter, when IsConsonant is invoked, then false is expected", r: 'k', want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConsonant(tt.r); got != tt.want {
+				t.Errorf("IsConsonant(%q) = %v, want %v", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWordRune(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want bool
+	}{
+		{name: "Given a consonant, when IsWordRune is invoked, then true is expected", r: 'क', want: true},
+		{name: "Given a vowel sign, when IsWordRune is invoked, then true is expected", r: 'ि', want: true},
+		{name: "Given an additional letter, when IsWordRune is invoked, then true is expected", r: 'ॻ', want: true},
+		{name: "Given a Vedic sign, when IsWordRune is invoked, then true is expected", r: '᳐', want: true},
+		{name: "Given a danda, when IsWordRune is invoked, then false is expected", r: '।', want: false},
+		{name: "Given a Devanagari digit, when IsWordRune is invoked, then false is expected", r: '५', want: false},
+		{name: "Given a zero width joiner, when IsWordRune is invoked, then false is expected", r: '\u200D', want: false},
+		{name: "Given a Latin letter, when IsWordRune is invoked, then false is expected", r: 'k', want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsWordRune(tt.r); got != tt.want {
+				t.Errorf("IsWordRune(%q) = %v, want %v", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldNasals(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "Given a half nasal consonant before a consonant, when FoldNasals is invoked, then anusvara is expected", text: "हिन्दी", want: "हिंदी"},
+		{name: "Given a half म before a consonant, when FoldNasals is invoked, then anusvara is expected", text: "सम्बन्ध", want: "संबंध"},
+		{name: "Given a nasal consonant with a vowel, when FoldNasals is invoked, then it is kept", text: "नमक", want: "नमक"},
+		{name: "Given a half nasal consonant at the end, when FoldNasals is invoked, then it is kept", text: "वाङ्", want: "वाङ्"},
+		{name: "Given a half nasal consonant before a vowel, when FoldNasals is invoked, then it is kept", text: "न्अ", want: "न्अ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FoldNasals(tt.text); got != tt.want {
+				t.Errorf("FoldNasals(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -14,8 +14,14 @@ import (
 type Configs struct {
 
 	// Dbtype mentions type of DB server used.
+	// Use `memory` to hold the lexicon in memory or `sqlite` to use a local SQLite file, neither needs a DB server.
 	Dbtype string `json:"type"`
 
+	// Path of the file used by the `memory` lexicon to load words from and save words to.
+	// Optional, if empty then words are not persisted.
+	// For `sqlite` this is the DB file location, `:memory:` creates a DB which lives only as long as the program.
+	Path string `json:"path"`
+
 	// Host address of the database server.
 	Host string `json:"host"`
 
@@ -33,6 +39,28 @@ type Configs struct {
 
 	// Authentication token. Not needed if username/password is configured.
 	AuthToken string `json:"authToken"`
+
+	// SSLMode used to connect to a PostgreSQL server, e.g. `require` or `verify-full`. Defaults to `disable`.
+	SSLMode string `json:"sslMode"`
+
+	// BatchSize is the count of words written to the DB by a single query when adding words. Optional, defaults to 1000.
+	BatchSize int `json:"batchSize"`
+
+	// AtomicAdd makes adding words all-or-nothing, if any batch fails then none of the words are added.
+	// By default every batch is committed on its own.
+	AtomicAdd bool `json:"atomicAdd"`
+
+	// Normalization is the Unicode normalization form to which words are converted before they are added,
+	// looked up or searched. One of `nfc`, `nfkc` or `none`. Optional, defaults to `nfc`.
+	Normalization string `json:"normalization"`
+
+	// Validation lists the rules a word must follow to be added, words breaking a rule are rejected with the reason.
+	// Any of `devanagari`, `leadingMark`, `maxLength` & `whitespace`. Optional, defaults to none, i.e. words are added
+	// as they are.
+	Validation []string `json:"validation"`
+
+	// MaxWordLength is the maximum number of characters in a word used by the `maxLength` rule. Optional, defaults to 100.
+	MaxWordLength int `json:"maxWordLength"`
 }
 
 func ReadConfigs(filePath string) *Configs {
@@ -47,7 +75,11 @@ func ReadConfigs(filePath string) *Configs {
 	}
 
 	// validate
-	if len(cfg.Host) == 0 {
+	if cfg.Dbtype == "sqlite" {
+		if len(cfg.Path) == 0 {
+			log.Panic("path is invalid")
+		}
+	} else if cfg.Dbtype != "memory" && len(cfg.Host) == 0 {
 		log.Panic("host is invalid")
 	}
 
@@ -4,10 +4,16 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	goio "io"
 	"os"
 	"strings"
 )
 
+const (
+	// StdinValue is the raw value which indicates that the input words should be read from the standard input.
+	StdinValue = "-"
+)
+
 var (
 	// errors
 	ErrNoInputValue = errors.New("raw value is empty or blank")
@@ -22,9 +28,44 @@ type SupplyInput interface {
 	Get(rawValue string) ([]string, error)
 }
 
-// A SupplyWordsFromCLI is one of the implementation of SupplyInput.
+// A SupplyInputStream defines interface to recieve program inputs one word at a time.
+// Unlike SupplyInput the words are not held in memory all at once, which suits very large inputs.
+type SupplyInputStream interface {
+	// Stream returns a WordIterator over the input words for the lexicon operation.
+	// `rawValue` is the unprocessed input value as recieved from the user interface/terminal.
+	// If `rawValue` is empty or blank, then error ErrNoInputValue is returned with nil iterator.
+	// If error is encountered while opening `rawValue`, nil iterator with error response is returned.
+	Stream(rawValue string) (WordIterator, error)
+}
+
+// A WordIterator yields the input words one at a time, it must be closed after use.
+//
+//	for it.Next() {
+//		word := it.Word()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type WordIterator interface {
+	// Next advances the iterator to the next word, it returns false when there are no more words or an
+	// error occurred.
+	Next() bool
+
+	// Word returns the current word.
+	Word() string
+
+	// Err returns the error, if any, encountered while reading the words.
+	Err() error
+
+	// Close releases the input held by the iterator.
+	Close() error
+}
+
+// A SupplyWordsFromCLI is one of the implementation of SupplyInput & SupplyInputStream.
 // It processes and treat the passed rawValue as a single word itself.
-type SupplyWordsFromCLI struct{}
+// If Split is set then the rawValue is treated as text and split into words by it instead.
+type SupplyWordsFromCLI struct {
+	Split bufio.SplitFunc // optional, e.g. tokenizer.ScanDevanagariWords
+}
 
 func (si *SupplyWordsFromCLI) Get(rawValue string) ([]string, error) {
 	value := strings.TrimSpace(rawValue)
@@ -33,14 +74,40 @@ func (si *SupplyWordsFromCLI) Get(rawValue string) ([]string, error) {
 		return nil, ErrNoInputValue
 	}
 
+	if si.Split != nil {
+		return collect(newScannerIterator(strings.NewReader(value), si.Split, noClose))
+	}
+
 	return []string{value}, nil
 }
 
-// A SupplyWordsFromFile is one of the implementation of SupplyInput.
+func (si *SupplyWordsFromCLI) Stream(rawValue string) (WordIterator, error) {
+	words, err := si.Get(rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sliceIterator{words: words, next: -1}, nil
+}
+
+// A SupplyWordsFromFile is one of the implementation of SupplyInput & SupplyInputStream.
 // It processes and treat the passed rawValue as a file path which contains words to be used as input.
-type SupplyWordsFromFile struct{}
+// Words are whitespace delimited unless Split is set.
+type SupplyWordsFromFile struct {
+	Split bufio.SplitFunc // optional, e.g. tokenizer.ScanDevanagariWords
+}
 
 func (si *SupplyWordsFromFile) Get(rawValue string) ([]string, error) {
+	it, err := si.Stream(rawValue)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	return collect(it)
+}
+
+func (si *SupplyWordsFromFile) Stream(rawValue string) (WordIterator, error) {
 	path := strings.TrimSpace(rawValue)
 
 	if len(path) == 0 {
@@ -52,18 +119,112 @@ func (si *SupplyWordsFromFile) Get(rawValue string) ([]string, error) {
 		return nil, fmt.Errorf("input: file is corrupt or file does not exist: %w", err)
 	}
 
-	words := make([]string, 0)
+	return newScannerIterator(file, si.Split, file.Close), nil
+}
 
-	// read file contents into `words`
-	scanner := bufio.NewScanner(file)
-	scanner.Split(bufio.ScanWords)
-	for scanner.Scan() {
-		words = append(words, scanner.Text())
+// A SupplyWordsFromStdin is one of the implementation of SupplyInput & SupplyInputStream.
+// It reads the words from the standard input, so words can be piped to the program, when rawValue is `-`.
+// Words are whitespace delimited unless Split is set, just like SupplyWordsFromFile.
+// Standard input can be read only once, so only one operation should use it.
+type SupplyWordsFromStdin struct {
+	Split bufio.SplitFunc // optional, e.g. tokenizer.ScanDevanagariWords
+}
+
+func (si *SupplyWordsFromStdin) Get(rawValue string) ([]string, error) {
+	it, err := si.Stream(rawValue)
+	if err != nil {
+		return nil, err
 	}
+	defer it.Close()
+
+	return collect(it)
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("input: file contents are invalid or file is corrupt: %w", err)
+func (si *SupplyWordsFromStdin) Stream(rawValue string) (WordIterator, error) {
+	if strings.TrimSpace(rawValue) != StdinValue {
+		return nil, ErrNoInputValue
+	}
+
+	// standard input belongs to the program, it is not closed with the iterator
+	return newScannerIterator(os.Stdin, si.Split, noClose), nil
+}
+
+// collect reads all the remaining words of the iterator into an array.
+func collect(it WordIterator) ([]string, error) {
+	words := make([]string, 0)
+	for it.Next() {
+		words = append(words, it.Word())
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
 	}
 
 	return words, nil
 }
+
+// scannerIterator is a WordIterator over the words read by a bufio.Scanner.
+type scannerIterator struct {
+	scanner *bufio.Scanner
+	close   func() error // releases the input read by the scanner
+}
+
+// newScannerIterator returns an iterator over words of the reader split by `split`, whitespace delimited
+// words if `split` is nil.
+func newScannerIterator(reader goio.Reader, split bufio.SplitFunc, close func() error) *scannerIterator {
+	if split == nil {
+		split = bufio.ScanWords
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(split)
+	return &scannerIterator{scanner: scanner, close: close}
+}
+
+// noClose is the close function of inputs which are not owned by the iterator.
+func noClose() error {
+	return nil
+}
+
+func (it *scannerIterator) Next() bool {
+	return it.scanner.Scan()
+}
+
+func (it *scannerIterator) Word() string {
+	return it.scanner.Text()
+}
+
+func (it *scannerIterator) Err() error {
+	if err := it.scanner.Err(); err != nil {
+		return fmt.Errorf("input: contents are invalid or file is corrupt: %w", err)
+	}
+
+	return nil
+}
+
+func (it *scannerIterator) Close() error {
+	return it.close()
+}
+
+// sliceIterator is a WordIterator over an array of words.
+type sliceIterator struct {
+	words []string
+	next  int // index of the current word
+}
+
+func (it *sliceIterator) Next() bool {
+	it.next++
+	return it.next < len(it.words)
+}
+
+func (it *sliceIterator) Word() string {
+	return it.words[it.next]
+}
+
+func (it *sliceIterator) Err() error {
+	return nil
+}
+
+func (it *sliceIterator) Close() error {
+	return nil
+}
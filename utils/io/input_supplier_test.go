@@ -0,0 +1,53 @@
+package io
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSupplyWordsFromFile_Stream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	os.WriteFile(path, []byte("नमस्ते धन्यवाद\n\n  नमस्कार\tसुंदर\n"), 0644)
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Given a file with whitespace delimited words, when Stream is invoked, then every word is yielded in order",
+			raw:  path,
+			want: []string{"नमस्ते", "धन्यवाद", "नमस्कार", "सुंदर"},
+		},
+		{
+			name:    "Given a blank raw value, when Stream is invoked, then ErrNoInputValue is expected",
+			raw:     "  ",
+			wantErr: ErrNoInputValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := (&SupplyWordsFromFile{}).Stream(tt.raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SupplyWordsFromFile.Stream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer it.Close()
+
+			got, err := collect(it)
+			if err != nil {
+				t.Fatalf("WordIterator error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SupplyWordsFromFile.Stream() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
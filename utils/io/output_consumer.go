@@ -1,10 +1,16 @@
 package io
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strings"
+
+	"github.com/vinaygaykar/cool-lexicon/lexicon/pkg"
+	"golang.org/x/term"
 )
 
 // A ConsumeOutput provides ability to consume output of any operation supported by the lexicon.
@@ -15,6 +21,28 @@ type ConsumeOutput interface {
 
 	// ConsumeMapOfWords will consume the given map where key is a word and value is array of words
 	ConsumeMapOfWords(operation string, output *map[string][]string)
+
+	// ConsumeNextPages will consume the given map where key is a searched word and value is the cursor of the next page
+	// of its words, see lexicon.SearchOptions.Next
+	ConsumeNextPages(operation string, cursors *map[string]string)
+
+	// ConsumeAddResult will consume the given result of an add operation
+	ConsumeAddResult(operation string, output *lexicon.AddResult)
+
+	// ConsumeMisspellings will consume the given unknown words found by a spell check
+	ConsumeMisspellings(operation string, output *[]Misspelling)
+
+	// Close will flush and release everything held for the consumed outputs
+	Close()
+}
+
+// A Misspelling is a word of a text which does not exist in the lexicon, along with its position in the text
+// and the suggested corrections.
+type Misspelling struct {
+	Word        string   `json:"word"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column"`
+	Suggestions []string `json:"suggestions"`
 }
 
 // A ConsumeOutputToLog is one of the implementation of ConsumeOutput which forwards the output
@@ -30,27 +58,183 @@ func (co *ConsumeOutputToLog) ConsumeMapOfWords(operation string, output *map[st
 	log.Printf("%s result: \n%v\n", operation, *output)
 }
 
+func (co *ConsumeOutputToLog) ConsumeNextPages(operation string, cursors *map[string]string) {
+	for _, key := range sortedKeys(*cursors) {
+		log.Printf("%s next page of (%s) : -after %s\n", operation, key, (*cursors)[key])
+	}
+}
+
+func (co *ConsumeOutputToLog) ConsumeAddResult(operation string, output *lexicon.AddResult) {
+	log.Printf("%s result: \ninserted (%d): %v\nexisting (%d): %v\nrejected (%d): %v\n", operation,
+		len(output.Inserted), output.Inserted, len(output.Existing), output.Existing, len(output.Rejected), output.Rejected)
+}
+
+func (co *ConsumeOutputToLog) ConsumeMisspellings(operation string, output *[]Misspelling) {
+	var sb strings.Builder
+	for _, misspelling := range *output {
+		sb.WriteString(fmt.Sprintf("%d:%d %s, suggestions: %v\n", misspelling.Line, misspelling.Column, misspelling.Word, misspelling.Suggestions))
+	}
+
+	log.Printf("%s result: \n%s", operation, sb.String())
+}
+
+func (co *ConsumeOutputToLog) Close() {}
+
+// A ConsumeOutputToStdout is one of the implementation of ConsumeOutput which prints the output to
+// the standard output in a plain format suited for pipes and other programs, one line per word
+// without any decoration. Words of a map are prefixed by their key and words of an add result by
+// their outcome, separated by a tab. Cursors of the next pages are printed as `next`, the key and the
+// cursor, separated by a tab. Misspellings are printed as their line:column, word and space separated
+// suggestions, separated by a tab.
+type ConsumeOutputToStdout struct{}
+
+func (co *ConsumeOutputToStdout) ConsumeWords(operation string, output *[]string) {
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, word := range *output {
+		fmt.Fprintln(w, word)
+	}
+}
+
+func (co *ConsumeOutputToStdout) ConsumeMapOfWords(operation string, output *map[string][]string) {
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, key := range sortedKeys(*output) {
+		for _, word := range (*output)[key] {
+			fmt.Fprintf(w, "%s\t%s\n", key, word)
+		}
+	}
+}
+
+func (co *ConsumeOutputToStdout) ConsumeNextPages(operation string, cursors *map[string]string) {
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, key := range sortedKeys(*cursors) {
+		fmt.Fprintf(w, "next\t%s\t%s\n", key, (*cursors)[key])
+	}
+}
+
+func (co *ConsumeOutputToStdout) ConsumeAddResult(operation string, output *lexicon.AddResult) {
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, word := range output.Inserted {
+		fmt.Fprintf(w, "inserted\t%s\n", word)
+	}
+	for _, word := range output.Existing {
+		fmt.Fprintf(w, "existing\t%s\n", word)
+	}
+	for _, rejected := range output.Rejected {
+		fmt.Fprintf(w, "rejected\t%s\t%s\n", rejected.Word, rejected.Reason)
+	}
+}
+
+func (co *ConsumeOutputToStdout) ConsumeMisspellings(operation string, output *[]Misspelling) {
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	for _, misspelling := range *output {
+		fmt.Fprintf(w, "%d:%d\t%s\t%s\n", misspelling.Line, misspelling.Column, misspelling.Word, strings.Join(misspelling.Suggestions, " "))
+	}
+}
+
+func (co *ConsumeOutputToStdout) Close() {}
+
+// IsTerminal checks if the given file, generally the standard output, is an interactive terminal
+// rather than a pipe, a regular file or a device like /dev/null.
+func IsTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
+
 // A ConsumeOutputToFile is one of the implementation of ConsumeOutput which forwards the output
 // to the provided file.
+// Every operation gets its own file, which is overwritten by the first output of the operation and
+// appended to by the subsequent outputs, so an operation can be consumed in parts.
+// Words are written one per line, other outputs as one JSON document per line, cursors of the next pages as
+// a document with the single field `next`.
 type ConsumeOutputToFile struct {
 	OutputFolderPath string
+
+	files map[string]*os.File // files opened for the operations
 }
 
 func (co *ConsumeOutputToFile) ConsumeWords(operation string, output *[]string) {
 	var sb strings.Builder
 	for _, word := range *output {
 		sb.WriteString(word)
+		sb.WriteString("\n")
 	}
 
-	path := co.OutputFolderPath+"/"+operation+".txt"
-	log.Printf("result of %s : %s\n", operation, path)
-	os.WriteFile(path, []byte(sb.String()), 0644)
+	co.write(operation, []byte(sb.String()))
 }
 
 func (co *ConsumeOutputToFile) ConsumeMapOfWords(operation string, output *map[string][]string) {
 	if jsonString, err := json.Marshal(output); err == nil {
-		path := co.OutputFolderPath+"/"+operation+".txt"
+		co.write(operation, append(jsonString, '\n'))
+	}
+}
+
+func (co *ConsumeOutputToFile) ConsumeNextPages(operation string, cursors *map[string]string) {
+	if jsonString, err := json.Marshal(map[string]*map[string]string{"next": cursors}); err == nil {
+		co.write(operation, append(jsonString, '\n'))
+	}
+}
+
+func (co *ConsumeOutputToFile) ConsumeAddResult(operation string, output *lexicon.AddResult) {
+	if jsonString, err := json.Marshal(output); err == nil {
+		co.write(operation, append(jsonString, '\n'))
+	}
+}
+
+func (co *ConsumeOutputToFile) ConsumeMisspellings(operation string, output *[]Misspelling) {
+	if jsonString, err := json.Marshal(output); err == nil {
+		co.write(operation, append(jsonString, '\n'))
+	}
+}
+
+func (co *ConsumeOutputToFile) Close() {
+	for operation, file := range co.files {
+		if err := file.Close(); err != nil {
+			log.Printf("could not write result of %s, error: %s\n", operation, err.Error())
+		}
+	}
+
+	co.files = nil
+}
+
+// write appends the content to the file of the operation, file is created on the first write.
+func (co *ConsumeOutputToFile) write(operation string, content []byte) {
+	file, ok := co.files[operation]
+	if !ok {
+		path := co.OutputFolderPath + "/" + operation + ".txt"
+		var err error
+		if file, err = os.Create(path); err != nil {
+			log.Printf("could not write result of %s, error: %s\n", operation, err.Error())
+			return
+		}
+
 		log.Printf("result of %s : %s\n", operation, path)
-		os.WriteFile(path, jsonString, 0644)
+		if co.files == nil {
+			co.files = make(map[string]*os.File)
+		}
+		co.files[operation] = file
+	}
+
+	if _, err := file.Write(content); err != nil {
+		log.Printf("could not write result of %s, error: %s\n", operation, err.Error())
 	}
 }
+
+// sortedKeys returns the keys of the map in order, so that the output does not change from run to run.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,74 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("%s not available: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+
+	file, _ := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	defer file.Close()
+
+	tests := []struct {
+		name string
+		file *os.File
+	}{
+		{
+			name: "Given the null device, when IsTerminal is invoked, then it is not a terminal",
+			file: devNull,
+		},
+		{
+			name: "Given a regular file, when IsTerminal is invoked, then it is not a terminal",
+			file: file,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if IsTerminal(tt.file) {
+				t.Errorf("IsTerminal() = true, want false")
+			}
+		})
+	}
+}
+
+func TestConsumeOutputToStdout_ConsumeMapOfWords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	out, _ := os.Create(path)
+	stdout := os.Stdout
+	os.Stdout = out
+	defer func() { os.Stdout = stdout }()
+
+	output := map[string][]string{"पा": {"पानी"}, "दे": {"देव", "देश"}, "क": {"कमल"}}
+	(&ConsumeOutputToStdout{}).ConsumeMapOfWords("ss", &output)
+	out.Close()
+
+	got, _ := os.ReadFile(path)
+	if want := "क\tकमल\nदे\tदेव\nदे\tदेश\nपा\tपानी\n"; string(got) != want {
+		t.Errorf("ConsumeMapOfWords() printed %q, want %q", got, want)
+	}
+}
+
+func TestConsumeOutputToStdout_ConsumeNextPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	out, _ := os.Create(path)
+	stdout := os.Stdout
+	os.Stdout = out
+	defer func() { os.Stdout = stdout }()
+
+	cursors := map[string]string{"पा": "पानी", "क": "कमल"}
+	(&ConsumeOutputToStdout{}).ConsumeNextPages("ss", &cursors)
+	out.Close()
+
+	got, _ := os.ReadFile(path)
+	if want := "next\tक\tकमल\nnext\tपा\tपानी\n"; string(got) != want {
+		t.Errorf("ConsumeNextPages() printed %q, want %q", got, want)
+	}
+}
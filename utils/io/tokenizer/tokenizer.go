@@ -0,0 +1,114 @@
+// Package tokenizer extracts Devanagari words from prose.
+//
+// A word is a run of Devanagari letters and signs (vowel signs, virama, nukta, anusvara, candrabindu,
+// visarga, avagraha etc.). Everything else, i.e. whitespace, punctuation including danda (।) & double
+// danda (॥), quotes, Devanagari & other digits and words of other scripts, only separates the words.
+// Zero width joiner & non-joiner are part of a word only when they are within the word, as they shape
+// the conjunct, a stray joiner at either end of a word is dropped.
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vinaygaykar/cool-lexicon/utils/akshara"
+)
+
+// ScanDevanagariWords is a bufio.SplitFunc which returns each Devanagari word of the text.
+func ScanDevanagariWords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// skip everything till the first word rune
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil // need rest of the rune
+		}
+
+		r, width := utf8.DecodeRune(data[start:])
+		if akshara.IsWordRune(r) {
+			break
+		}
+		start += width
+	}
+
+	// scan till the first non word rune, `end` is just past the last word rune so trailing joiners are excluded
+	end := start
+	for i := start; i < len(data); {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return start, nil, nil // need rest of the rune
+		}
+
+		r, width := utf8.DecodeRune(data[i:])
+		if akshara.IsWordRune(r) {
+			i += width
+			end = i
+		} else if r == akshara.ZWJ || r == akshara.ZWNJ {
+			i += width
+		} else {
+			return i, data[start:end], nil
+		}
+	}
+
+	if !atEOF {
+		return start, nil, nil // word may continue, need more data
+	} else if end > start {
+		return len(data), data[start:end], nil
+	}
+
+	return len(data), nil, nil
+}
+
+// Tokenize returns all the Devanagari words of the text in order.
+func Tokenize(text string) []string {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Split(ScanDevanagariWords)
+
+	words := make([]string, 0)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+
+	return words
+}
+
+// A Token is a Devanagari word of a text along with its position, line and column start from 1 and the column
+// counts characters (code points) rather than bytes.
+type Token struct {
+	Word   string
+	Line   int
+	Column int
+}
+
+// ScanTokens reads the text line by line and invokes `fn` for every Devanagari word in order.
+// Reading stops at the first error returned by `fn`, which is then returned.
+func ScanTokens(r io.Reader, fn func(token Token) error) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		for offset := 0; offset < len(data); {
+			advance, word, _ := ScanDevanagariWords(data[offset:], true)
+			if word == nil {
+				break
+			}
+
+			// only non word runes precede the word within the scanned part, so its first occurrence is the word
+			start := offset + bytes.Index(data[offset:offset+advance], word)
+			token := Token{Word: string(word), Line: line, Column: utf8.RuneCount(data[:start]) + 1}
+			if fnErr := fn(token); fnErr != nil {
+				return fnErr
+			}
+			offset += advance
+		}
+
+		if err != nil {
+			return nil // io.EOF
+		}
+	}
+}
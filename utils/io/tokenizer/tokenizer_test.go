@@ -0,0 +1,101 @@
+package tokenizer
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "Given a sentence ending with danda, when Tokenize is invoked, then danda is dropped",
+			text: "मी घरी जातो।",
+			want: []string{"मी", "घरी", "जातो"},
+		},
+		{
+			name: "Given text with quotes, commas and double danda, when Tokenize is invoked, then only words remain",
+			text: "\"राम\", 'सीता' आणि लक्ष्मण॥",
+			want: []string{"राम", "सीता", "आणि", "लक्ष्मण"},
+		},
+		{
+			name: "Given text with Devanagari and Latin digits, when Tokenize is invoked, then numerals are dropped",
+			text: "२०२३ साली 15 दिवस",
+			want: []string{"साली", "दिवस"},
+		},
+		{
+			name: "Given text with Latin fragments, when Tokenize is invoked, then Latin words are dropped",
+			text: "हा word मराठीत आहे (Marathi)",
+			want: []string{"हा", "मराठीत", "आहे"},
+		},
+		{
+			name: "Given a word with zero width joiners inside, when Tokenize is invoked, then joiners are kept",
+			text: "क्\u200Dष आणि र्\u200Cय",
+			want: []string{"क्\u200Dष", "आणि", "र्\u200Cय"},
+		},
+		{
+			name: "Given stray zero width joiners around words, when Tokenize is invoked, then joiners are dropped",
+			text: "\u200Dनमस्ते\u200C \u200C",
+			want: []string{"नमस्ते"},
+		},
+		{
+			name: "Given text with avagraha, nukta and abbreviation sign, when Tokenize is invoked, then abbreviation sign separates words",
+			text: "शिवोऽहम् ज़रूर डॉ॰ स॰",
+			want: []string{"शिवोऽहम्", "ज़रूर", "डॉ", "स"},
+		},
+		{
+			name: "Given text without any Devanagari, when Tokenize is invoked, then no words are returned",
+			text: "hello, 123!",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanDevanagariWords_OneByteReader(t *testing.T) {
+	// runes and words split across reads must be reassembled
+	text := "नमस्कार, क्\u200Dष। धन्यवाद"
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(text)))
+	scanner.Split(ScanDevanagariWords)
+
+	got := make([]string, 0)
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+
+	want := []string{"नमस्कार", "क्\u200Dष", "धन्यवाद"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanDevanagariWords() = %q, want %q", got, want)
+	}
+}
+
+func TestScanTokens(t *testing.T) {
+	text := "नमस्कार, जग।\n\n\"धन्यवाद\" आणि hello राम"
+
+	got := make([]Token, 0)
+	err := ScanTokens(iotest.OneByteReader(strings.NewReader(text)), func(token Token) error {
+		got = append(got, token)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanTokens() error = %v", err)
+	}
+
+	want := []Token{{"नमस्कार", 1, 1}, {"जग", 1, 10}, {"धन्यवाद", 3, 2}, {"आणि", 3, 11}, {"राम", 3, 21}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanTokens() = %v, want %v", got, want)
+	}
+}